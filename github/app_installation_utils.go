@@ -0,0 +1,268 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation"
+	"github.com/google/go-github/v55/github"
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+)
+
+// appInstallations lists the installations of the GitHub App configured for
+// the connection, using a JWT signed with the app's private key.
+type appInstallations struct {
+	// client is authenticated as the app itself, rather than an installation
+	client        *github.Client
+	appsTransport *ghinstallation.AppsTransport
+
+	mu            sync.Mutex
+	installations []*github.Installation
+	listedAt      time.Time
+}
+
+// appInstallationsTTL is how long the list of installations of the app is
+// used to route queries before it is listed again, so that installations
+// added or removed since are picked up.
+const appInstallationsTTL = 5 * time.Minute
+
+// newAppsTransport creates the transport that authenticates as the app
+// itself, and from which the installation transports mint their tokens.
+func newAppsTransport(config *clientConfig) (*ghinstallation.AppsTransport, error) {
+	atr, err := ghinstallation.NewAppsTransport(config.Transport, config.AppId, config.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error creating GitHub App client: %v", err)
+	}
+	if config.BaseURL != nil && config.BaseURL.String() != "https://api.github.com/" {
+		atr.BaseURL = strings.TrimSuffix(config.BaseURL.String(), "/") + "/api/v3"
+	}
+	return atr, nil
+}
+
+// getAppInstallations returns the installations of the connection's GitHub
+// App. It requires the app_id and app_private_key connection config.
+func getAppInstallations(ctx context.Context, d *plugin.QueryData) (*appInstallations, error) {
+
+	// Load app installations from cache
+	cacheKey := "github_app_installations"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*appInstallations), nil
+	}
+
+	config, err := getClientConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	if config.AppId == 0 {
+		return nil, &configError{Field: "app_id", Message: "'app_id' and 'app_private_key' must be set to list the installations of a GitHub App"}
+	}
+
+	atr, err := newAppsTransport(config)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newRESTClient(config, &http.Client{Transport: atr})
+	if err != nil {
+		return nil, err
+	}
+
+	installations := &appInstallations{
+		client:        client,
+		appsTransport: atr,
+	}
+
+	// Save to cache
+	d.ConnectionManager.Cache.Set(cacheKey, installations)
+
+	return installations, nil
+}
+
+// list returns every installation of the app. The list is reused for routing
+// queries until it is older than appInstallationsTTL.
+func (a *appInstallations) list(ctx context.Context) ([]*github.Installation, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.installations != nil && time.Since(a.listedAt) < appInstallationsTTL {
+		return a.installations, nil
+	}
+
+	installations, err := listAppInstallations(ctx, a.client)
+	if err != nil {
+		return nil, err
+	}
+	a.installations = installations
+	a.listedAt = time.Now()
+	return installations, nil
+}
+
+func listAppInstallations(ctx context.Context, client *github.Client) ([]*github.Installation, error) {
+	opts := &github.ListOptions{PerPage: 100}
+
+	var installations []*github.Installation
+	for {
+		items, resp, err := client.Apps.ListInstallations(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		installations = append(installations, items...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return installations, nil
+}
+
+// getInstallationCredentialPool returns the credential pool for the
+// installation of the app on the account that owns the organization or
+// repository the query is scoped to. Queries that aren't scoped to an account
+// with an installation, such as those of github_gitignore or of a public
+// repository of another account, are sent with the oldest installation of
+// the app. Installation tokens are only minted when a pool first sends a
+// request.
+func getInstallationCredentialPool(ctx context.Context, d *plugin.QueryData, config *clientConfig) (*credentialPool, error) {
+	installations, err := getAppInstallations(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	all, err := installations.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selectInstallation(all, queryOwners(d))
+	if err != nil {
+		return nil, fmt.Errorf("the GitHub App %d %w", config.AppId, err)
+	}
+	return installationCredentialPool(ctx, d, config, installations, selected), nil
+}
+
+// installationCredentialPool returns the credential pool for an installation
+// of the app.
+func installationCredentialPool(ctx context.Context, d *plugin.QueryData, config *clientConfig, installations *appInstallations, selected *github.Installation) *credentialPool {
+	name := fmt.Sprintf("installation %d", selected.GetID())
+
+	// Load credential pool from cache
+	cacheKey := "github_credential_pool_" + name
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*credentialPool)
+	}
+
+	credentials := []*credential{{
+		name:           name,
+		kind:           credentialKindAppInstallation,
+		transport:      config.HTTPCache.transport(ghinstallation.NewFromAppsTransport(installations.appsTransport, selected.GetID()), installationCacheCredential(config, selected.GetID())),
+		installationID: selected.GetID(),
+	}}
+
+	plugin.Logger(ctx).Debug("installationCredentialPool", "pool", name)
+	pool := newCredentialPool(name, credentials, config.WaitForReset, config.MaxWait, config.MinRemaining)
+
+	// Save to cache
+	d.ConnectionManager.Cache.Set(cacheKey, pool)
+
+	return pool
+}
+
+// getInstallationFanOut returns the clients that list the repositories to fan
+// out to with the installation on the account of each owner, as a query
+// without a qualifier is otherwise sent with the oldest installation, which
+// can't list the private repositories of other accounts.
+func getInstallationFanOut(ctx context.Context, d *plugin.QueryData, config *clientConfig) (*installationFanOut, error) {
+	installations, err := getAppInstallations(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	all, err := installations.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &installationFanOut{
+		appID:         config.AppId,
+		installations: all,
+		client: func(ctx context.Context, installation *github.Installation) (*github.Client, error) {
+			q := installationCredentialPool(ctx, d, config, installations, installation).forQuery(d.QueryContext)
+			return queryRESTClient(config, q)
+		},
+	}, nil
+}
+
+// installationCacheCredential identifies an installation of the connection's
+// GitHub App in the keys of the HTTP cache. Installation tokens expire hourly,
+// so the installation stands in for its token.
+func installationCacheCredential(config *clientConfig, installationID int64) string {
+	return fmt.Sprintf("app %d installation %d", config.AppId, installationID)
+}
+
+// selectInstallation returns the installation on the accounts of the owners,
+// or the oldest installation if none of them has one. Owners without an
+// installation are skipped, as the resources of other accounts may still be
+// public. Errors complete a sentence about the app.
+func selectInstallation(installations []*github.Installation, owners []string) (*github.Installation, error) {
+	if len(installations) == 0 {
+		return nil, fmt.Errorf("has no installations")
+	}
+
+	var selected *github.Installation
+	for _, owner := range owners {
+		for _, i := range installations {
+			if !strings.EqualFold(i.GetAccount().GetLogin(), owner) {
+				continue
+			}
+			if selected != nil && selected.GetID() != i.GetID() {
+				return nil, fmt.Errorf("is installed separately on the accounts %s and %s, so they must be queried separately", selected.GetAccount().GetLogin(), owner)
+			}
+			selected = i
+		}
+	}
+	if selected != nil {
+		return selected, nil
+	}
+
+	oldest := installations[0]
+	for _, i := range installations[1:] {
+		if i.GetID() < oldest.GetID() {
+			oldest = i
+		}
+	}
+	return oldest, nil
+}
+
+// queryOwners returns the logins of the accounts that own the organization or
+// repository the query is scoped to, from its organization or
+// repository_full_name qualifier. The login qualifier is not used, as tables
+// such as github_user look up any account, whether or not the app is
+// installed on it. The SDK runs a separate call for each value of an `in`
+// list of a key column, but any list left is routed as a whole.
+func queryOwners(d *plugin.QueryData) []string {
+	for _, column := range []string{"organization", "repository_full_name"} {
+		q := d.EqualsQuals[column]
+		if q == nil {
+			continue
+		}
+		values := []*proto.QualValue{q}
+		if list := q.GetListValue(); list != nil {
+			values = list.Values
+		}
+		var owners []string
+		for _, v := range values {
+			owner := v.GetStringValue()
+			if column == "repository_full_name" {
+				owner, _ = parseRepoFullName(owner)
+			}
+			if owner != "" {
+				owners = append(owners, owner)
+			}
+		}
+		if len(owners) > 0 {
+			return owners
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestSelectInstallation(t *testing.T) {
+	installation := func(id int64, login string) *github.Installation {
+		return &github.Installation{ID: github.Int64(id), Account: &github.User{Login: github.String(login)}}
+	}
+	installations := []*github.Installation{installation(30, "acme"), installation(10, "octo-org"), installation(20, "octocat")}
+
+	cases := []struct {
+		name          string
+		installations []*github.Installation
+		owners        []string
+		wantID        int64
+		wantErr       string
+	}{
+		{
+			name:          "unscoped query",
+			installations: installations,
+			wantID:        10,
+		},
+		{
+			name:          "installed on the owner",
+			installations: installations,
+			owners:        []string{"ACME"},
+			wantID:        30,
+		},
+		{
+			name:          "owner without an installation",
+			installations: installations,
+			owners:        []string{"torvalds"},
+			wantID:        10,
+		},
+		{
+			name:          "owners with and without an installation",
+			installations: installations,
+			owners:        []string{"torvalds", "octocat", "octocat"},
+			wantID:        20,
+		},
+		{
+			name:          "owners with different installations",
+			installations: installations,
+			owners:        []string{"acme", "octocat"},
+			wantErr:       "installed separately on the accounts acme and octocat",
+		},
+		{
+			name:    "no installations",
+			owners:  []string{"acme"},
+			wantErr: "has no installations",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := selectInstallation(tc.installations, tc.owners)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.GetID() != tc.wantID {
+				t.Errorf("got installation %d, want %d", got.GetID(), tc.wantID)
+			}
+		})
+	}
+}
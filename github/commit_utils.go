@@ -22,7 +22,7 @@ func appendCommitColumnIncludes(m *map[string]interface{}, cols []string) {
 	// For BasicCommit struct
 	(*m)["includeCommitShortSha"] = githubv4.Boolean(slices.Contains(cols, "short_sha"))
 	(*m)["includeCommitAuthoredDate"] = githubv4.Boolean(slices.Contains(cols, "authored_date"))
-	(*m)["includeCommitAuthor"] = githubv4.Boolean(slices.Contains(cols, "author") || slices.Contains(cols, "author_login"))
+	(*m)["includeCommitAuthor"] = githubv4.Boolean(slices.Contains(cols, "author") || slices.Contains(cols, "author_login") || slices.Contains(cols, "author_email"))
 	(*m)["includeCommitCommittedDate"] = githubv4.Boolean(slices.Contains(cols, "committed_date"))
 	(*m)["includeCommitCommitter"] = githubv4.Boolean(slices.Contains(cols, "committer") || slices.Contains(cols, "committer_login"))
 	(*m)["includeCommitMessage"] = githubv4.Boolean(slices.Contains(cols, "message"))
@@ -54,6 +54,14 @@ func commitHydrateAuthorLogin(_ context.Context, _ *plugin.QueryData, h *plugin.
 	return commit.Author.User.Login, nil
 }
 
+func commitHydrateAuthorEmail(_ context.Context, _ *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	commit, err := extractCommitFromHydrateItem(h)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Author.Email, nil
+}
+
 func commitHydrateCommitterLogin(_ context.Context, _ *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	commit, err := extractCommitFromHydrateItem(h)
 	if err != nil {
@@ -33,13 +33,15 @@ func getLoginId(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 
 // Build a cache key for the call to getLoginIdCacheKey.
 func getLoginIdCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	key := "getLoginId"
-	return key, nil
+	return viewerCacheKey(ctx, d, "getLoginId")
 }
 
 func getLoginIdUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	var query struct {
 		Viewer struct {
@@ -47,7 +49,7 @@ func getLoginIdUncached(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 			ID    githubv4.ID
 		}
 	}
-	err := client.Query(ctx, &query, nil)
+	err = client.Query(ctx, &query, nil)
 	if err != nil {
 		plugin.Logger(ctx).Error("getLoginIdUncached", "api_error", err)
 		return nil, err
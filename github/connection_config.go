@@ -1,17 +1,68 @@
 package github
 
 import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation"
 	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
 )
 
 type githubConfig struct {
-	Token          *string `hcl:"token"`
-	BaseURL        *string `hcl:"base_url"`
-	AppId          *string `hcl:"app_id"`
-	InstallationId *string `hcl:"app_installation_id"`
-	PrivateKey     *string `hcl:"app_private_key"`
+	Token           *string  `hcl:"token"`
+	Tokens          []string `hcl:"tokens,optional"`
+	BaseURL         *string  `hcl:"base_url"`
+	AppId           *string  `hcl:"app_id"`
+	InstallationId  *string  `hcl:"app_installation_id"`
+	InstallationIds []string `hcl:"app_installation_ids,optional"`
+	PrivateKey      *string  `hcl:"app_private_key"`
+
+	RateLimitStrategy *string `hcl:"rate_limit_strategy"`
+	RateLimitMaxWait  *int    `hcl:"rate_limit_max_wait"`
+
+	RateLimitMinRemaining *int `hcl:"rate_limit_min_remaining"`
+
+	CACertFile         *string `hcl:"ca_cert_file"`
+	InsecureSkipVerify *bool   `hcl:"insecure_skip_verify"`
+	ProxyURL           *string `hcl:"proxy_url"`
+	ClientCertFile     *string `hcl:"client_cert_file"`
+	ClientKeyFile      *string `hcl:"client_key_file"`
+
+	Repositories                []string `hcl:"repositories,optional"`
+	Organizations               []string `hcl:"organizations,optional"`
+	RepositoryFanOutConcurrency *int     `hcl:"repository_fan_out_concurrency"`
+
+	HTTPCache          *bool   `hcl:"http_cache"`
+	HTTPCacheDir       *string `hcl:"http_cache_dir"`
+	HTTPCacheMaxSizeMB *int    `hcl:"http_cache_max_size_mb"`
+	HTTPCacheTTL       *int    `hcl:"http_cache_ttl"`
+
+	WorkflowJobLogMaxLines  *int `hcl:"workflow_job_log_max_lines"`
+	WorkflowJobLogMaxSizeMB *int `hcl:"workflow_job_log_max_size_mb"`
 }
 
+const (
+	rateLimitStrategyFail = "fail"
+	rateLimitStrategyWait = "wait"
+)
+
+// defaultRateLimitMaxWait is the longest a request waits for a rate limit
+// reset when rate_limit_max_wait is not set. The primary rate limit resets
+// hourly.
+const defaultRateLimitMaxWait = time.Hour
+
 func ConfigInstance() interface{} {
 	return &githubConfig{}
 }
@@ -24,3 +75,370 @@ func GetConfig(connection *plugin.Connection) githubConfig {
 	config, _ := connection.GetConfig().(githubConfig)
 	return config
 }
+
+// rateLimitWait returns whether requests that hit a rate limit should wait
+// for the limit to reset instead of failing, and the longest they may wait.
+func (c githubConfig) rateLimitWait() (bool, time.Duration) {
+	if c.RateLimitStrategy == nil || *c.RateLimitStrategy != rateLimitStrategyWait {
+		return false, 0
+	}
+	if c.RateLimitMaxWait != nil {
+		return true, time.Duration(*c.RateLimitMaxWait) * time.Second
+	}
+	return true, defaultRateLimitMaxWait
+}
+
+// configError is returned when the connection config, or the environment
+// variable standing in for it, is invalid.
+type configError struct {
+	// Field is the name of the offending argument in the connection config
+	Field   string
+	Message string
+}
+
+func (e *configError) Error() string {
+	return fmt.Sprintf("invalid connection config argument '%s': %s. Edit your connection configuration file and then restart Steampipe", e.Field, e.Message)
+}
+
+// clientConfig is the connection config resolved against the environment
+// and validated, ready for the clients to be built from.
+type clientConfig struct {
+	// BaseURL is nil for github.com
+	BaseURL *url.URL
+
+	Tokens          []string
+	AppId           int64
+	InstallationIds []int64
+	PrivateKey      []byte
+
+	// DiscoverInstallations is set when no installation ID is configured, so
+	// that every installation of the app is used
+	DiscoverInstallations bool
+
+	WaitForReset bool
+	MaxWait      time.Duration
+	MinRemaining int
+
+	// Transport is the base transport of every client, configured with the
+	// connection's TLS and proxy settings
+	Transport http.RoundTripper
+
+	// Repositories and Organizations are the patterns repository scoped
+	// tables fan out across when queried without a repository_full_name
+	Repositories  []string
+	Organizations []string
+	// FanOutSlots bounds how many repositories are listed at once
+	FanOutSlots chan struct{}
+
+	// HTTPCache is nil unless http_cache is enabled
+	HTTPCache *httpCache
+
+	// JobLogMaxLines and JobLogMaxSize cap how much of a workflow job log
+	// is read
+	JobLogMaxLines int
+	JobLogMaxSize  int64
+}
+
+// loadClientConfig resolves the connection config against the environment
+// variables and validates it, so that a bad argument is reported by name
+// rather than failing when a client is first used.
+func loadClientConfig(config githubConfig) (*clientConfig, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	baseURL := os.Getenv("GITHUB_BASE_URL")
+	githubAppId := os.Getenv("GITHUB_APP_ID")
+	githubInstallationId := os.Getenv("GITHUB_APP_INSTALLATION_ID")
+	githubPrivateKey := os.Getenv("GITHUB_APP_PEM_FILE")
+	if privateKey := os.Getenv("GITHUB_APP_PRIVATE_KEY"); privateKey != "" {
+		githubPrivateKey = privateKey
+	}
+
+	if config.Token != nil {
+		token = *config.Token
+	}
+	if config.BaseURL != nil {
+		baseURL = *config.BaseURL
+	}
+
+	// Github App authentication.
+	if config.AppId != nil {
+		githubAppId = *config.AppId
+	}
+	if config.InstallationId != nil {
+		githubInstallationId = *config.InstallationId
+	}
+	if config.PrivateKey != nil {
+		githubPrivateKey = *config.PrivateKey
+	}
+
+	result := &clientConfig{}
+
+	if baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, &configError{Field: "base_url", Message: fmt.Sprintf("'%s' is not an absolute URL, e.g. https://github.example.com", baseURL)}
+		}
+		result.BaseURL = u
+	}
+
+	// Return error for unsupported token by prefix
+	// https://docs.github.com/en/authentication/keeping-your-account-and-data-secure/about-authentication-to-github#githubs-token-formats
+	if token != "" {
+		if !isValidPersonalAccessTokenPrefix(token) && !isValidInstallationOrOAuthAccessTokenPrefix(token) {
+			return nil, &configError{Field: "token", Message: "supported token formats are 'ghs_', 'gho_', 'ghp_', and 'github_pat'"}
+		}
+		result.Tokens = append(result.Tokens, token)
+	}
+	for i, t := range config.Tokens {
+		if !isValidPersonalAccessTokenPrefix(t) && !isValidInstallationOrOAuthAccessTokenPrefix(t) {
+			return nil, &configError{Field: fmt.Sprintf("tokens[%d]", i), Message: "supported token formats are 'ghs_', 'gho_', 'ghp_', and 'github_pat'"}
+		}
+		result.Tokens = append(result.Tokens, t)
+	}
+
+	appConfigured := githubAppId != "" || githubInstallationId != "" || len(config.InstallationIds) > 0 || githubPrivateKey != ""
+	appKeyed := githubAppId != "" && githubPrivateKey != ""
+
+	// With a token configured, app_installation_id is ignored as it always
+	// has been. Installations are only pooled with tokens when they are
+	// listed in app_installation_ids.
+	if len(result.Tokens) > 0 {
+		githubInstallationId = ""
+	}
+	hasInstallations := githubInstallationId != "" || len(config.InstallationIds) > 0
+	if len(result.Tokens) == 0 && !appConfigured {
+		return nil, &configError{Field: "token", Message: "'token', 'tokens' or 'app_id' and 'app_private_key' must be set"}
+	}
+
+	// With only the app ID and private key configured, the installations of
+	// the app are discovered and each query is routed to the installation
+	// that owns the organization or repository it is scoped to
+	result.DiscoverInstallations = appKeyed && !hasInstallations && len(result.Tokens) == 0
+
+	// Github App authentication requires both the app ID and private key,
+	// unless tokens are configured, in which case a partial app config is
+	// ignored as it always has been
+	if appConfigured && !appKeyed && len(result.Tokens) == 0 {
+		if githubAppId == "" {
+			return nil, &configError{Field: "app_id", Message: "must be set to authenticate as a GitHub App installation"}
+		}
+		return nil, &configError{Field: "app_private_key", Message: "must be set to authenticate as a GitHub App installation"}
+	}
+
+	if appKeyed && (hasInstallations || result.DiscoverInstallations) {
+		appId, err := strconv.ParseInt(githubAppId, 10, 64)
+		if err != nil {
+			return nil, &configError{Field: "app_id", Message: fmt.Sprintf("'%s' is not a numeric ID", githubAppId)}
+		}
+		result.AppId = appId
+
+		if githubInstallationId != "" {
+			installationId, err := strconv.ParseInt(githubInstallationId, 10, 64)
+			if err != nil {
+				return nil, &configError{Field: "app_installation_id", Message: fmt.Sprintf("'%s' is not a numeric ID", githubInstallationId)}
+			}
+			result.InstallationIds = append(result.InstallationIds, installationId)
+		}
+		for i, id := range config.InstallationIds {
+			installationId, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return nil, &configError{Field: fmt.Sprintf("app_installation_ids[%d]", i), Message: fmt.Sprintf("'%s' is not a numeric ID", id)}
+			}
+			result.InstallationIds = append(result.InstallationIds, installationId)
+		}
+
+		privateKey, err := loadPrivateKey(githubPrivateKey)
+		if err != nil {
+			return nil, &configError{Field: "app_private_key", Message: err.Error()}
+		}
+		if _, err := ghinstallation.NewAppsTransport(http.DefaultTransport, appId, privateKey); err != nil {
+			return nil, &configError{Field: "app_private_key", Message: fmt.Sprintf("unable to parse private key: %s", err)}
+		}
+		result.PrivateKey = privateKey
+	}
+
+	if config.RateLimitStrategy != nil && *config.RateLimitStrategy != rateLimitStrategyWait && *config.RateLimitStrategy != rateLimitStrategyFail {
+		return nil, &configError{Field: "rate_limit_strategy", Message: "supported values are 'wait' and 'fail'"}
+	}
+	if config.RateLimitMaxWait != nil && *config.RateLimitMaxWait < 0 {
+		return nil, &configError{Field: "rate_limit_max_wait", Message: "must not be negative"}
+	}
+	if config.RateLimitMinRemaining != nil {
+		if *config.RateLimitMinRemaining < 0 {
+			return nil, &configError{Field: "rate_limit_min_remaining", Message: "must not be negative"}
+		}
+		result.MinRemaining = *config.RateLimitMinRemaining
+	}
+	result.WaitForReset, result.MaxWait = config.rateLimitWait()
+
+	transport, err := newBaseTransport(config)
+	if err != nil {
+		return nil, err
+	}
+	result.Transport = transport
+
+	for i, p := range config.Repositories {
+		pattern := strings.TrimPrefix(p, "!")
+		if _, err := path.Match(pattern, ""); err != nil || strings.Count(pattern, "/") != 1 {
+			return nil, &configError{Field: fmt.Sprintf("repositories[%d]", i), Message: fmt.Sprintf("'%s' is not a repository full name or pattern, e.g. acme/* or !acme/archive-*", p)}
+		}
+	}
+	for i, o := range config.Organizations {
+		if _, err := path.Match(o, ""); err != nil || o == "" || strings.Contains(o, "/") {
+			return nil, &configError{Field: fmt.Sprintf("organizations[%d]", i), Message: fmt.Sprintf("'%s' is not an organization login or pattern, e.g. acme or acme-*", o)}
+		}
+	}
+	result.Repositories = config.Repositories
+	result.Organizations = config.Organizations
+	concurrency := defaultFanOutConcurrency
+	if config.RepositoryFanOutConcurrency != nil {
+		if *config.RepositoryFanOutConcurrency < 1 {
+			return nil, &configError{Field: "repository_fan_out_concurrency", Message: "must be at least 1"}
+		}
+		concurrency = *config.RepositoryFanOutConcurrency
+	}
+	result.FanOutSlots = make(chan struct{}, concurrency)
+
+	result.JobLogMaxLines = defaultJobLogMaxLines
+	if config.WorkflowJobLogMaxLines != nil {
+		if *config.WorkflowJobLogMaxLines < 1 {
+			return nil, &configError{Field: "workflow_job_log_max_lines", Message: "must be at least 1"}
+		}
+		result.JobLogMaxLines = *config.WorkflowJobLogMaxLines
+	}
+	result.JobLogMaxSize = defaultJobLogMaxSize
+	if config.WorkflowJobLogMaxSizeMB != nil {
+		if *config.WorkflowJobLogMaxSizeMB < 1 {
+			return nil, &configError{Field: "workflow_job_log_max_size_mb", Message: "must be at least 1"}
+		}
+		result.JobLogMaxSize = int64(*config.WorkflowJobLogMaxSizeMB) << 20
+	}
+
+	cache, err := newHTTPCacheFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	result.HTTPCache = cache
+
+	return result, nil
+}
+
+// newHTTPCacheFromConfig returns the REST response cache of the connection,
+// or nil if http_cache is not enabled.
+func newHTTPCacheFromConfig(config githubConfig) (*httpCache, error) {
+	if config.HTTPCacheMaxSizeMB != nil && *config.HTTPCacheMaxSizeMB <= 0 {
+		return nil, &configError{Field: "http_cache_max_size_mb", Message: "must be greater than 0"}
+	}
+	if config.HTTPCacheTTL != nil && *config.HTTPCacheTTL <= 0 {
+		return nil, &configError{Field: "http_cache_ttl", Message: "must be greater than 0"}
+	}
+	if config.HTTPCache == nil || !*config.HTTPCache {
+		return nil, nil
+	}
+
+	maxSize := int64(defaultHTTPCacheMaxSize)
+	if config.HTTPCacheMaxSizeMB != nil {
+		maxSize = int64(*config.HTTPCacheMaxSizeMB) << 20
+	}
+	ttl := defaultHTTPCacheTTL
+	if config.HTTPCacheTTL != nil {
+		ttl = time.Duration(*config.HTTPCacheTTL) * time.Second
+	}
+
+	var dir string
+	if config.HTTPCacheDir != nil {
+		dir = *config.HTTPCacheDir
+	} else {
+		defaultDir, err := defaultHTTPCacheDir()
+		if err != nil {
+			return nil, &configError{Field: "http_cache_dir", Message: fmt.Sprintf("must be set, as there is no default cache directory: %s", err)}
+		}
+		dir = defaultDir
+	}
+	return newHTTPCache(dir, maxSize, ttl), nil
+}
+
+// newBaseTransport returns the transport all requests are sent with, which is
+// http.DefaultTransport unless the connection configures TLS or a proxy, as
+// is common for GitHub Enterprise Server behind an internal CA.
+func newBaseTransport(config githubConfig) (http.RoundTripper, error) {
+	if config.CACertFile == nil && config.InsecureSkipVerify == nil && config.ProxyURL == nil && config.ClientCertFile == nil && config.ClientKeyFile == nil {
+		return http.DefaultTransport, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.CACertFile != nil {
+		pem, err := os.ReadFile(*config.CACertFile)
+		if err != nil {
+			return nil, &configError{Field: "ca_cert_file", Message: fmt.Sprintf("unable to read CA bundle: %s", err)}
+		}
+		// Trust the bundle in addition to the system roots, so that a proxy
+		// with its own CA doesn't break github.com
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, &configError{Field: "ca_cert_file", Message: "no PEM encoded certificates found"}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.InsecureSkipVerify != nil {
+		tlsConfig.InsecureSkipVerify = *config.InsecureSkipVerify
+	}
+
+	if config.ClientCertFile != nil || config.ClientKeyFile != nil {
+		if config.ClientCertFile == nil {
+			return nil, &configError{Field: "client_cert_file", Message: "must be set together with 'client_key_file'"}
+		}
+		if config.ClientKeyFile == nil {
+			return nil, &configError{Field: "client_key_file", Message: "must be set together with 'client_cert_file'"}
+		}
+		cert, err := tls.LoadX509KeyPair(*config.ClientCertFile, *config.ClientKeyFile)
+		if err != nil {
+			return nil, &configError{Field: "client_cert_file", Message: fmt.Sprintf("unable to load client certificate: %s", err)}
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if config.ProxyURL != nil {
+		proxyURL, err := url.Parse(*config.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, &configError{Field: "proxy_url", Message: "must be an absolute URL, e.g. http://proxy.example.com:3128"}
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// loadPrivateKey returns the PEM encoded GitHub App private key given either
+// as PEM content, base64 encoded PEM content or the path of a PEM file. The
+// key is only ever held in memory, and errors never include the value, as it
+// may be the key itself.
+func loadPrivateKey(value string) ([]byte, error) {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "-----BEGIN") {
+		return []byte(trimmed), nil
+	}
+
+	// Base64 encoded keys may be wrapped across lines
+	if decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(trimmed), "")); err == nil {
+		if bytes.HasPrefix(bytes.TrimSpace(decoded), []byte("-----BEGIN")) {
+			return bytes.TrimSpace(decoded), nil
+		}
+	}
+
+	privateKey, err := os.ReadFile(value)
+	if err != nil {
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			err = pathErr.Err
+		}
+		return nil, fmt.Errorf("must be a PEM encoded key, a base64 encoded PEM key or the path of a PEM file, and reading it as a file failed: %s", err)
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,517 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"weak"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/turbot/steampipe-plugin-github/github/models"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+)
+
+// credential is a single authenticated transport in a credentialPool. The
+// rate limit budget of each resource (core, graphql, search, ...) is tracked
+// separately, as GitHub does.
+type credential struct {
+	// name is a non-secret label used for logging, e.g. "token 1"
+	name      string
+	kind      credentialKind
+	transport http.RoundTripper
+	// installationID is the ID of the GitHub App installation the credential
+	// authenticates as, for credentials of kind credentialKindAppInstallation
+	installationID int64
+
+	mu      sync.Mutex
+	budgets map[string]*rateLimitBudget
+}
+
+// rateLimitBudget is the last known rate limit state of a credential for a
+// single rate limit resource.
+type rateLimitBudget struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// credentialPool spreads queries across several credentials. Each query is
+// pinned to the credential with the most remaining budget when it starts, and
+// every request of the query is sent with that credential, so that the
+// viewer scoped tables answer for a single identity. A query only moves to
+// another credential once its credential has run out of budget, see
+// queryCredential.failover.
+type credentialPool struct {
+	// name identifies the pool among the pools of the connection, e.g.
+	// "default" or "installation 1234"
+	name        string
+	credentials []*credential
+
+	// waitForReset parks requests that hit a rate limit until the limit of
+	// their credential resets, provided that is no more than maxWait away
+	waitForReset bool
+	maxWait      time.Duration
+
+	// minRemaining is the remaining budget of the pool below which requests
+	// are spaced out, rather than sent as soon as a hydrate makes them
+	minRemaining int
+	throttleMu   sync.Mutex
+	nextSlot     map[string]time.Time
+
+	// pins holds the credential of each running query, keyed by its query
+	// context, which the copies of the query data made for each hydrate
+	// share. A pin is dropped once its query context is garbage collected.
+	pinsMu sync.Mutex
+	pins   map[weak.Pointer[plugin.QueryContext]]*queryCredential
+}
+
+// queryCredential is the credential a query is pinned to, and the clients
+// that send the query's requests with it.
+type queryCredential struct {
+	pool *credentialPool
+
+	mu         sync.Mutex
+	credential *credential
+	rest       *github.Client
+	v4         *githubv4.Client
+}
+
+// maxThrottleDelay caps the spacing between requests while the pool is below
+// its minimum remaining budget. Throttling is meant to keep concurrent
+// hydrates clear of the secondary rate limits, not to stretch the budget to
+// the reset.
+const maxThrottleDelay = 10 * time.Second
+
+func newCredentialPool(name string, credentials []*credential, waitForReset bool, maxWait time.Duration, minRemaining int) *credentialPool {
+	for _, c := range credentials {
+		c.budgets = map[string]*rateLimitBudget{}
+	}
+	return &credentialPool{
+		name:         name,
+		credentials:  credentials,
+		waitForReset: waitForReset,
+		maxWait:      maxWait,
+		minRemaining: minRemaining,
+		nextSlot:     map[string]time.Time{},
+		pins:         map[weak.Pointer[plugin.QueryContext]]*queryCredential{},
+	}
+}
+
+// forQuery returns the credential the query is pinned to, pinning it to the
+// best credential of the pool on first use.
+func (p *credentialPool) forQuery(qc *plugin.QueryContext) *queryCredential {
+	p.pinsMu.Lock()
+	defer p.pinsMu.Unlock()
+	if qc == nil {
+		return &queryCredential{pool: p, credential: p.pick()}
+	}
+	key := weak.Make(qc)
+	if q, ok := p.pins[key]; ok {
+		return q
+	}
+	q := &queryCredential{pool: p, credential: p.pick()}
+	p.pins[key] = q
+	runtime.AddCleanup(qc, p.unpin, key)
+	return q
+}
+
+func (p *credentialPool) unpin(key weak.Pointer[plugin.QueryContext]) {
+	p.pinsMu.Lock()
+	defer p.pinsMu.Unlock()
+	delete(p.pins, key)
+}
+
+// pick returns the credential with the most remaining core and GraphQL
+// budget, or the one whose budget resets first if every credential is
+// exhausted. Credentials that have not been used yet are preferred, so that
+// their budget becomes known, and ties go to the credential with the fewest
+// running queries. The caller holds pinsMu.
+func (p *credentialPool) pick() *credential {
+	running := map[*credential]int{}
+	for _, q := range p.pins {
+		running[q.credential]++
+	}
+
+	var best *credential
+	bestRemaining := 0
+	for _, c := range p.credentials {
+		remaining := min(c.remaining("core"), c.remaining("graphql"))
+		if remaining == 0 {
+			continue
+		}
+		if best == nil || remaining > bestRemaining || (remaining == bestRemaining && running[c] < running[best]) {
+			best = c
+			bestRemaining = remaining
+		}
+	}
+	if best == nil {
+		best = p.soonestReset("core")
+	}
+	return best
+}
+
+// next returns the credential with the most remaining budget for the
+// resource, or nil if every credential has run out.
+func (p *credentialPool) next(resource string) *credential {
+	var best *credential
+	for _, c := range p.credentials {
+		if remaining := c.remaining(resource); remaining > 0 && (best == nil || remaining > best.remaining(resource)) {
+			best = c
+		}
+	}
+	return best
+}
+
+// current returns the credential the query is pinned to.
+func (q *queryCredential) current() *credential {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.credential
+}
+
+// failover pins the query to the credential of the pool with the most
+// remaining budget for the resource, if the credential it is pinned to has
+// run out. It returns false if no credential has any budget left.
+func (q *queryCredential) failover(ctx context.Context, resource string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.credential.remaining(resource) > 0 {
+		return true
+	}
+	next := q.pool.next(resource)
+	if next == nil {
+		return false
+	}
+	plugin.Logger(ctx).Warn("queryCredential.failover", "pool", q.pool.name, "from", q.credential.name, "to", next.name, "resource", resource)
+	q.credential = next
+	return true
+}
+
+// failoverRateLimitError pins the query to another credential of the pool
+// once go-github reports that its credential has run out of budget. go-github
+// fails requests without sending them while the last response it saw had no
+// budget left, so the REST client's view of the budget is refreshed from the
+// rate limit endpoint, which doesn't count against the rate limit.
+func failoverRateLimitError(ctx context.Context, d *plugin.QueryData, e *github.RateLimitError) bool {
+	q, err := getQueryCredential(ctx, d)
+	if err != nil || len(q.pool.credentials) < 2 {
+		return false
+	}
+	resource := "core"
+	if e.Response != nil && e.Response.Request != nil {
+		resource = rateLimitResourceForRequest(e.Response.Request)
+	}
+	if !q.failover(ctx, resource) {
+		return false
+	}
+
+	q.mu.Lock()
+	client := q.rest
+	q.mu.Unlock()
+	if client != nil {
+		if _, _, err := client.RateLimits(ctx); err != nil {
+			plugin.Logger(ctx).Error("failoverRateLimitError", "rate_limits_error", err)
+			return false
+		}
+	}
+	return true
+}
+
+func (q *queryCredential) RoundTrip(req *http.Request) (*http.Response, error) {
+	p := q.pool
+	resource := rateLimitResourceForRequest(req)
+	if err := p.throttle(req.Context(), resource); err != nil {
+		return nil, err
+	}
+
+	for {
+		c := q.current()
+		resp, err := c.transport.RoundTrip(replay(req))
+		if err != nil {
+			return nil, err
+		}
+		c.update(resource, resp.Header)
+		if !canReplay(req) {
+			return resp, nil
+		}
+
+		// Send the request again with another credential of the pool if
+		// this one has run out of budget
+		if isRateLimitedResponse(resp) && q.failover(req.Context(), rateLimitResource(req, resp)) && q.current() != c {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			continue
+		}
+
+		if !p.waitForReset {
+			return resp, nil
+		}
+		wait, limited := c.rateLimitWait(req, resp)
+		if !limited || wait > p.maxWait {
+			return resp, nil
+		}
+		// Allow for clock skew between GitHub and the host
+		wait = max(wait, 0) + time.Second
+		plugin.Logger(req.Context()).Warn("credentialPool.RoundTrip", "credential", c.name, "waiting_for_rate_limit_reset", wait.String(), "url", req.URL.Path)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleepWithContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// throttle delays the request while the remaining budget of the pool for the
+// resource is below minRemaining.
+func (p *credentialPool) throttle(ctx context.Context, resource string) error {
+	delay := p.throttleDelay(resource)
+	if delay <= 0 {
+		return nil
+	}
+	plugin.Logger(ctx).Debug("credentialPool.throttle", "resource", resource, "delay", delay.String())
+	return sleepWithContext(ctx, delay)
+}
+
+// throttleDelay returns how long to delay a request for the resource. While
+// the remaining budget is below minRemaining, requests are given consecutive
+// slots, spaced so that the remaining budget would last until the reset,
+// which serialises concurrent hydrates.
+func (p *credentialPool) throttleDelay(resource string) time.Duration {
+	if p.minRemaining <= 0 {
+		return 0
+	}
+	remaining, reset, known := p.budget(resource)
+	if !known || remaining >= p.minRemaining {
+		return 0
+	}
+
+	interval := maxThrottleDelay
+	if remaining > 0 {
+		interval = min(time.Until(reset)/time.Duration(remaining), maxThrottleDelay)
+	}
+
+	p.throttleMu.Lock()
+	defer p.throttleMu.Unlock()
+	slot := time.Now()
+	if next := p.nextSlot[resource]; next.After(slot) {
+		slot = next
+	}
+	p.nextSlot[resource] = slot.Add(interval)
+	return time.Until(slot)
+}
+
+// budget returns the remaining budget of the pool for the resource, summed
+// across credentials, and the soonest time a credential's budget resets.
+// known is false until every credential has reported its budget.
+func (p *credentialPool) budget(resource string) (remaining int, reset time.Time, known bool) {
+	for _, c := range p.credentials {
+		r := c.remaining(resource)
+		if r == math.MaxInt {
+			return 0, time.Time{}, false
+		}
+		remaining += r
+		if t := c.reset(resource); reset.IsZero() || t.Before(reset) {
+			reset = t
+		}
+	}
+	return remaining, reset, true
+}
+
+// observeRateLimit records the rate limit reported in the RateLimit fragment
+// of a GraphQL query sent with the credential.
+func (c *credential) observeRateLimit(resource string, rateLimits *models.RateLimit) {
+	if rateLimits.ResetAt.IsZero() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.budgets[resource] = &rateLimitBudget{
+		Limit:     rateLimits.Limit,
+		Remaining: rateLimits.Remaining,
+		Reset:     rateLimits.ResetAt,
+	}
+}
+
+// rateLimitWait returns how long to wait before the request can be sent
+// again, if the response is a primary or secondary rate limit error.
+func (c *credential) rateLimitWait(req *http.Request, resp *http.Response) (time.Duration, bool) {
+	if isRateLimitedResponse(resp) {
+		return time.Until(c.reset(rateLimitResource(req, resp))), true
+	}
+
+	if isSecondaryRateLimitedResponse(req, resp) {
+		// https://docs.github.com/en/rest/using-the-rest-api/best-practices-for-using-the-rest-api#handle-rate-limit-errors-appropriately
+		if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			return time.Duration(retryAfter) * time.Second, true
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			reset, _ := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+			return time.Until(time.Unix(reset, 0)), true
+		}
+		return time.Minute, true
+	}
+
+	return 0, false
+}
+
+// soonestReset returns the credential whose budget for the resource resets
+// first.
+func (p *credentialPool) soonestReset(resource string) *credential {
+	soonest := p.credentials[0]
+	for _, c := range p.credentials[1:] {
+		if c.reset(resource).Before(soonest.reset(resource)) {
+			soonest = c
+		}
+	}
+	return soonest
+}
+
+// remaining returns the remaining budget of the credential for the resource,
+// or math.MaxInt if it is unknown or has been reset since last seen.
+func (c *credential) remaining(resource string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.budgets[resource]
+	if !ok || time.Now().After(b.Reset) {
+		return math.MaxInt
+	}
+	return b.Remaining
+}
+
+// reset returns the time the budget of the credential for the resource
+// resets, or the zero time if it is unknown.
+func (c *credential) reset(resource string) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if b, ok := c.budgets[resource]; ok {
+		return b.Reset
+	}
+	return time.Time{}
+}
+
+// update records the rate limit state reported in the X-RateLimit-* response
+// headers.
+func (c *credential) update(resource string, header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if r := header.Get("X-RateLimit-Resource"); r != "" {
+		resource = r
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.budgets[resource] = &rateLimitBudget{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+}
+
+// rateLimitResourceForRequest returns the rate limit resource a request is
+// expected to be counted against.
+func rateLimitResourceForRequest(req *http.Request) string {
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/graphql"):
+		return "graphql"
+	case strings.Contains(req.URL.Path, "/search/"):
+		return "search"
+	default:
+		return "core"
+	}
+}
+
+// rateLimitResource returns the rate limit resource a response was counted
+// against.
+func rateLimitResource(req *http.Request, resp *http.Response) string {
+	if resource := resp.Header.Get("X-RateLimit-Resource"); resource != "" {
+		return resource
+	}
+	return rateLimitResourceForRequest(req)
+}
+
+// isRateLimitedResponse reports whether GitHub rejected the request because
+// the primary rate limit of the credential was exhausted. REST returns a 403
+// or 429, whereas GraphQL returns a 200 with a RATE_LIMITED error.
+func isRateLimitedResponse(resp *http.Response) bool {
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return true
+	case http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return err == nil && bytes.Contains(body, []byte(`"RATE_LIMITED"`))
+	}
+	return false
+}
+
+// isSecondaryRateLimitedResponse reports whether GitHub rejected the request
+// because of a secondary rate limit. REST returns a 403 or 429, whereas
+// GraphQL may also return a 200 with the error.
+func isSecondaryRateLimitedResponse(req *http.Request, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if resp.Header.Get("Retry-After") != "" {
+			return true
+		}
+	case http.StatusOK:
+		if rateLimitResourceForRequest(req) != "graphql" {
+			return false
+		}
+	default:
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return err == nil && bytes.Contains(body, []byte("secondary rate limit"))
+}
+
+// canReplay reports whether the request can be sent more than once.
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// replay returns a copy of the request with a fresh body, so that it can be
+// sent again after a previous attempt consumed the body.
+func replay(req *http.Request) *http.Request {
+	if req.GetBody == nil {
+		return req
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return req
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone
+}
+
+// sleepWithContext waits for the duration, returning early with the context
+// error if the context is cancelled first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
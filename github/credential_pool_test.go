@@ -0,0 +1,407 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"weak"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// rateLimitedTransport answers every request with the remaining budget it is
+// given, counting down, and with a 403 rate limit error once it is spent.
+func rateLimitedTransport(remaining int, sent *int) http.RoundTripper {
+	reset := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		*sent++
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}")), Request: req}
+		if remaining == 0 {
+			resp.StatusCode = http.StatusForbidden
+			resp.Body = io.NopCloser(strings.NewReader(`{"message":"API rate limit exceeded"}`))
+		} else {
+			remaining--
+		}
+		resp.Header.Set("X-RateLimit-Limit", "5000")
+		resp.Header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		resp.Header.Set("X-RateLimit-Reset", reset)
+		resp.Header.Set("X-RateLimit-Resource", "core")
+		return resp, nil
+	})
+}
+
+func testBudget(remaining int, reset time.Duration) map[string]*rateLimitBudget {
+	budget := &rateLimitBudget{Limit: 5000, Remaining: remaining, Reset: time.Now().Add(reset)}
+	return map[string]*rateLimitBudget{"core": budget, "graphql": budget}
+}
+
+func TestCredentialPoolPick(t *testing.T) {
+	cases := []struct {
+		name    string
+		budgets []map[string]*rateLimitBudget
+		running []int
+		want    int
+	}{
+		{
+			name:    "most remaining",
+			budgets: []map[string]*rateLimitBudget{testBudget(10, time.Hour), testBudget(3000, time.Hour), testBudget(200, time.Hour)},
+			want:    1,
+		},
+		{
+			name:    "unknown budget first",
+			budgets: []map[string]*rateLimitBudget{testBudget(3000, time.Hour), nil},
+			want:    1,
+		},
+		{
+			name:    "budget reset since last seen",
+			budgets: []map[string]*rateLimitBudget{testBudget(3000, time.Hour), testBudget(0, -time.Minute)},
+			want:    1,
+		},
+		{
+			name:    "fewest running queries on a tie",
+			budgets: []map[string]*rateLimitBudget{testBudget(100, time.Hour), testBudget(100, time.Hour)},
+			running: []int{2, 1},
+			want:    1,
+		},
+		{
+			name:    "soonest reset when exhausted",
+			budgets: []map[string]*rateLimitBudget{testBudget(0, time.Hour), testBudget(0, time.Minute), testBudget(0, 30*time.Minute)},
+			want:    1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var credentials []*credential
+			for i := range tc.budgets {
+				credentials = append(credentials, &credential{name: "token " + strconv.Itoa(i)})
+			}
+			p := newCredentialPool("default", credentials, false, 0, 0)
+			for i, budgets := range tc.budgets {
+				if budgets != nil {
+					credentials[i].budgets = budgets
+				}
+			}
+			// Pin queries to make up the running counts
+			var running []*plugin.QueryContext
+			for i, n := range tc.running {
+				for j := 0; j < n; j++ {
+					qc := &plugin.QueryContext{}
+					running = append(running, qc)
+					p.pins[weak.Make(qc)] = &queryCredential{pool: p, credential: credentials[i]}
+				}
+			}
+
+			p.pinsMu.Lock()
+			got := p.pick()
+			p.pinsMu.Unlock()
+			if got != credentials[tc.want] {
+				t.Errorf("picked %s, want %s", got.name, credentials[tc.want].name)
+			}
+			runtime.KeepAlive(running)
+		})
+	}
+}
+
+func TestCredentialPoolForQueryPins(t *testing.T) {
+	var sentA, sentB int
+	a := &credential{name: "token 0", transport: rateLimitedTransport(100, &sentA)}
+	b := &credential{name: "token 1", transport: rateLimitedTransport(5000, &sentB)}
+	p := newCredentialPool("default", []*credential{a, b}, false, 0, 0)
+
+	qc := &plugin.QueryContext{}
+	q := p.forQuery(qc)
+	if q.current() != a {
+		t.Fatalf("expected the query to be pinned to %s, got %s", a.name, q.current().name)
+	}
+	if p.forQuery(qc) != q {
+		t.Fatal("expected the same query context to get the same credential")
+	}
+
+	// The query stays on its credential although the other has more budget
+	for i := 0; i < 3; i++ {
+		getBody(t, q, "https://api.github.com/user/repos?page="+strconv.Itoa(i))
+	}
+	if q.current() != a || sentA != 3 || sentB != 0 {
+		t.Errorf("expected every request to be sent with %s, got %d and %d requests", a.name, sentA, sentB)
+	}
+
+	// A new query is pinned to the credential with the most budget
+	if other := p.forQuery(&plugin.QueryContext{}); other.current() != b {
+		t.Errorf("expected a new query to be pinned to %s, got %s", b.name, other.current().name)
+	}
+}
+
+func TestCredentialPoolFailover(t *testing.T) {
+	cases := []struct {
+		name       string
+		remaining  []int
+		wantStatus int
+		wantSent   []int
+	}{
+		{
+			name:       "moves to the credential with budget",
+			remaining:  []int{0, 0, 10},
+			wantStatus: http.StatusOK,
+			wantSent:   []int{1, 1, 1},
+		},
+		{
+			name:       "returns the rate limit error when every credential is spent",
+			remaining:  []int{0, 0},
+			wantStatus: http.StatusForbidden,
+			wantSent:   []int{1, 1},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sent := make([]int, len(tc.remaining))
+			var credentials []*credential
+			for i, remaining := range tc.remaining {
+				credentials = append(credentials, &credential{name: "token " + strconv.Itoa(i), transport: rateLimitedTransport(remaining, &sent[i])})
+			}
+			p := newCredentialPool("default", credentials, false, 0, 0)
+			// Every credential looks to have budget left until it reports
+			// its own
+			for _, c := range credentials {
+				c.budgets = testBudget(1, time.Hour)
+			}
+			q := &queryCredential{pool: p, credential: credentials[0]}
+
+			resp, _ := getBody(t, q, "https://api.github.com/repos/o/r")
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if resp.Header.Get("X-RateLimit-Remaining") != strconv.Itoa(max(tc.remaining[len(tc.remaining)-1]-1, 0)) {
+				t.Errorf("expected the rate limit headers of the last credential, got remaining %s", resp.Header.Get("X-RateLimit-Remaining"))
+			}
+			for i, want := range tc.wantSent {
+				if sent[i] != want {
+					t.Errorf("%s sent %d requests, want %d", credentials[i].name, sent[i], want)
+				}
+			}
+		})
+	}
+}
+
+// rateLimitHeaders returns response headers reporting the remaining budget
+// and a reset the given duration from now. Empty values are left out.
+func rateLimitHeaders(remaining string, reset *time.Duration) http.Header {
+	header := http.Header{}
+	if remaining != "" {
+		header.Set("X-RateLimit-Remaining", remaining)
+	}
+	if reset != nil {
+		header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(*reset).Unix(), 10))
+	}
+	return header
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
+
+func TestCredentialPoolThrottle(t *testing.T) {
+	cases := []struct {
+		name         string
+		minRemaining int
+		headers      []http.Header
+		// wantInterval is the expected delay of the second request, the
+		// first is always sent straight away
+		wantInterval time.Duration
+	}{
+		{
+			name:         "throttling disabled",
+			minRemaining: 0,
+			headers:      []http.Header{rateLimitHeaders("1", durationPtr(time.Hour))},
+		},
+		{
+			name:         "missing headers",
+			minRemaining: 100,
+			headers:      []http.Header{rateLimitHeaders("", nil)},
+		},
+		{
+			name:         "missing reset header",
+			minRemaining: 100,
+			headers:      []http.Header{rateLimitHeaders("10", nil)},
+		},
+		{
+			name:         "above the minimum",
+			minRemaining: 100,
+			headers:      []http.Header{rateLimitHeaders("500", durationPtr(time.Hour))},
+		},
+		{
+			name:         "reset in the past",
+			minRemaining: 100,
+			headers:      []http.Header{rateLimitHeaders("10", durationPtr(-time.Minute))},
+		},
+		{
+			name:         "below the minimum",
+			minRemaining: 100,
+			headers:      []http.Header{rateLimitHeaders("10", durationPtr(20*time.Second))},
+			wantInterval: 2 * time.Second,
+		},
+		{
+			name:         "spread across credentials",
+			minRemaining: 100,
+			headers:      []http.Header{rateLimitHeaders("5", durationPtr(20*time.Second)), rateLimitHeaders("5", durationPtr(time.Hour))},
+			wantInterval: 2 * time.Second,
+		},
+		{
+			name:         "one credential unknown",
+			minRemaining: 100,
+			headers:      []http.Header{rateLimitHeaders("5", durationPtr(20*time.Second)), rateLimitHeaders("", nil)},
+		},
+		{
+			name:         "exhausted",
+			minRemaining: 100,
+			headers:      []http.Header{rateLimitHeaders("0", durationPtr(time.Hour))},
+			wantInterval: maxThrottleDelay,
+		},
+		{
+			name:         "capped",
+			minRemaining: 100,
+			headers:      []http.Header{rateLimitHeaders("1", durationPtr(time.Hour))},
+			wantInterval: maxThrottleDelay,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var credentials []*credential
+			for i := range tc.headers {
+				credentials = append(credentials, &credential{name: "token " + strconv.Itoa(i)})
+			}
+			p := newCredentialPool("default", credentials, false, 0, tc.minRemaining)
+			for i, header := range tc.headers {
+				credentials[i].update("core", header)
+			}
+
+			if first := p.throttleDelay("core"); first > 0 {
+				t.Errorf("expected the first request to be sent straight away, got a delay of %s", first)
+			}
+			// The reset headers have a resolution of a second
+			second := p.throttleDelay("core")
+			if second < tc.wantInterval-time.Second || second > tc.wantInterval {
+				t.Errorf("got a delay of %s, want %s", second, tc.wantInterval)
+			}
+			if other := p.throttleDelay("search"); other > 0 {
+				t.Errorf("expected other resources not to be throttled, got a delay of %s", other)
+			}
+		})
+	}
+}
+
+func TestCredentialRateLimitWait(t *testing.T) {
+	cases := []struct {
+		name        string
+		path        string
+		status      int
+		header      http.Header
+		body        string
+		wantLimited bool
+		wantWait    time.Duration
+	}{
+		{
+			name:   "not limited",
+			status: http.StatusOK,
+			header: rateLimitHeaders("10", durationPtr(time.Hour)),
+			body:   "{}",
+		},
+		{
+			name:   "forbidden with budget left",
+			status: http.StatusForbidden,
+			header: rateLimitHeaders("10", durationPtr(time.Hour)),
+			body:   `{"message":"Resource not accessible by integration"}`,
+		},
+		{
+			name:        "primary rate limit",
+			status:      http.StatusForbidden,
+			header:      rateLimitHeaders("0", durationPtr(time.Hour)),
+			body:        `{"message":"API rate limit exceeded"}`,
+			wantLimited: true,
+			wantWait:    time.Hour,
+		},
+		{
+			name:        "primary rate limit reset in the past",
+			status:      http.StatusTooManyRequests,
+			header:      rateLimitHeaders("0", durationPtr(-time.Minute)),
+			wantLimited: true,
+			wantWait:    -time.Minute,
+		},
+		{
+			name:        "graphql rate limit",
+			status:      http.StatusOK,
+			header:      rateLimitHeaders("0", durationPtr(10*time.Minute)),
+			body:        `{"errors":[{"type":"RATE_LIMITED"}]}`,
+			wantLimited: true,
+			wantWait:    10 * time.Minute,
+		},
+		{
+			name:        "secondary rate limit with retry after",
+			status:      http.StatusForbidden,
+			header:      http.Header{"Retry-After": []string{"30"}},
+			wantLimited: true,
+			wantWait:    30 * time.Second,
+		},
+		{
+			name:        "secondary rate limit without headers",
+			status:      http.StatusForbidden,
+			header:      http.Header{},
+			body:        `{"message":"You have exceeded a secondary rate limit."}`,
+			wantLimited: true,
+			wantWait:    time.Minute,
+		},
+		{
+			name:        "graphql secondary rate limit with retry after",
+			path:        "/graphql",
+			status:      http.StatusOK,
+			header:      http.Header{"Retry-After": []string{"45"}},
+			body:        `{"errors":[{"message":"You have exceeded a secondary rate limit."}]}`,
+			wantLimited: true,
+			wantWait:    45 * time.Second,
+		},
+		{
+			name:        "graphql secondary rate limit without headers",
+			path:        "/graphql",
+			status:      http.StatusOK,
+			header:      http.Header{},
+			body:        `{"errors":[{"message":"You have exceeded a secondary rate limit."}]}`,
+			wantLimited: true,
+			wantWait:    time.Minute,
+		},
+		{
+			name:   "rest content mentioning the secondary rate limit",
+			status: http.StatusOK,
+			header: rateLimitHeaders("10", durationPtr(time.Hour)),
+			body:   `{"content":"Handle a secondary rate limit"}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &credential{name: "token 0", budgets: map[string]*rateLimitBudget{}}
+			path := tc.path
+			if path == "" {
+				path = "/repos/o/r"
+			}
+			req, _ := http.NewRequest(http.MethodGet, "https://api.github.com"+path, nil)
+			resp := &http.Response{StatusCode: tc.status, Header: tc.header, Body: io.NopCloser(strings.NewReader(tc.body))}
+			c.update("core", resp.Header)
+
+			wait, limited := c.rateLimitWait(req, resp)
+			if limited != tc.wantLimited {
+				t.Fatalf("got limited %t, want %t", limited, tc.wantLimited)
+			}
+			if wait < tc.wantWait-time.Second || wait > tc.wantWait {
+				t.Errorf("got a wait of %s, want %s", wait, tc.wantWait)
+			}
+		})
+	}
+}
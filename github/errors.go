@@ -20,6 +20,12 @@ func shouldRetryError(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	}
 
 	if e, ok := err.(*github.RateLimitError); ok {
+		// Retry with another credential of the pool if one has budget left
+		if failoverRateLimitError(ctx, d, e) {
+			plugin.Logger(ctx).Debug("github_errors.shouldRetryError", "rate_limit_error", err, "failover", true)
+			return true
+		}
+
 		// Get the limit reset timestamp if returned
 		var resetAfter time.Time
 		if e.Rate.String() != "" {
@@ -31,12 +37,21 @@ func shouldRetryError(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 		diff := resetAfter.Sub(t1).Seconds()
 		plugin.Logger(ctx).Debug("github_errors.shouldRetryError", "rate_limit_error", err, "reset_after", diff)
 
+		// Park the call until the limit resets if the connection is configured
+		// to wait, rather than fail
+		if config, err := getClientConfig(ctx, d); err == nil && config.WaitForReset {
+			if waited, ok := waitForRateLimitReset(ctx, config, resetAfter); ok {
+				return waited
+			}
+		}
+
 		// Treat the error as non-fatal if the remaining time for limit reset is
 		// less than 60s
 		return diff <= 60
 	}
 
-	// v4 secondary rate limit
+	// v4 secondary rate limit. With the wait strategy the credential pool
+	// already waited for Retry-After, unless that was over the max wait
 	if strings.Contains(err.Error(), "You have exceeded a secondary rate limit.") {
 		plugin.Logger(ctx).Debug("github_errors.shouldRetryError", "abuse_rate_limit_error", err)
 		return true
@@ -51,6 +66,18 @@ func shouldRetryError(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	return false
 }
 
+// waitForRateLimitReset parks the call until the rate limit resets, plus a
+// second to allow for clock skew. ok is false if the reset is further away
+// than the connection's max wait, and waited is false if the context was
+// cancelled while waiting.
+func waitForRateLimitReset(ctx context.Context, config *clientConfig, reset time.Time) (waited bool, ok bool) {
+	if time.Until(reset) > config.MaxWait {
+		return false, false
+	}
+	plugin.Logger(ctx).Warn("github_errors.shouldRetryError", "waiting_for_rate_limit_reset", reset)
+	return sleepWithContext(ctx, time.Until(reset)+time.Second) == nil, true
+}
+
 func retryConfig() *plugin.RetryConfig {
 	return &plugin.RetryConfig{
 		ShouldRetryErrorFunc: shouldRetryError,
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/context_key"
+)
+
+func TestWaitForRateLimitReset(t *testing.T) {
+	cases := []struct {
+		name       string
+		reset      time.Duration
+		maxWait    time.Duration
+		cancel     time.Duration
+		wantOK     bool
+		wantWaited bool
+		// wantElapsed bounds how long the call may take
+		wantElapsed time.Duration
+	}{
+		{
+			name:        "reset within max wait",
+			reset:       -time.Minute,
+			maxWait:     time.Hour,
+			wantOK:      true,
+			wantWaited:  true,
+			wantElapsed: 2 * time.Second,
+		},
+		{
+			name:        "reset beyond max wait",
+			reset:       2 * time.Hour,
+			maxWait:     time.Hour,
+			wantOK:      false,
+			wantElapsed: 100 * time.Millisecond,
+		},
+		{
+			name:        "zero max wait",
+			reset:       time.Minute,
+			maxWait:     0,
+			wantOK:      false,
+			wantElapsed: 100 * time.Millisecond,
+		},
+		{
+			name:        "cancelled while waiting",
+			reset:       30 * time.Minute,
+			maxWait:     time.Hour,
+			cancel:      50 * time.Millisecond,
+			wantOK:      true,
+			wantWaited:  false,
+			wantElapsed: time.Second,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+			if tc.cancel > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.cancel)
+				defer cancel()
+			}
+			config := &clientConfig{WaitForReset: true, MaxWait: tc.maxWait}
+
+			start := time.Now()
+			waited, ok := waitForRateLimitReset(ctx, config, time.Now().Add(tc.reset))
+			if elapsed := time.Since(start); elapsed > tc.wantElapsed {
+				t.Errorf("took %s, want at most %s", elapsed, tc.wantElapsed)
+			}
+			if ok != tc.wantOK || waited != tc.wantWaited {
+				t.Errorf("got waited %t ok %t, want waited %t ok %t", waited, ok, tc.wantWaited, tc.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newFakeGitHub starts a stand-in for a GitHub Enterprise Server API, which
+// the plugin is pointed at with the base_url config. Requests are answered by
+// the transport, which matches them on their path relative to the API root.
+func newFakeGitHub(t *testing.T, transport http.RoundTripper) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := r.Clone(r.Context())
+		req.RequestURI = ""
+		req.URL.Scheme = "http"
+		req.URL.Host = r.Host
+
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Errorf("fake GitHub: %s %s: %s", r.Method, r.URL, err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+
+		for name, values := range resp.Header {
+			// The body is re-encoded by the server
+			if name == "Content-Length" || name == "Content-Encoding" || name == "Transfer-Encoding" {
+				continue
+			}
+			for _, value := range values {
+				w.Header().Add(name, value)
+			}
+		}
+		w.WriteHeader(resp.StatusCode)
+		if _, err := io.Copy(w, resp.Body); err != nil {
+			t.Errorf("fake GitHub: %s %s: writing response: %s", r.Method, r.URL, err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
@@ -0,0 +1,287 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/turbot/steampipe-plugin-github/github/models"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+)
+
+// graphQLBatchWait is the longest a lookup waits for others to join its
+// batch. The SDK runs the list call of each value of a `where full_name in
+// (...)` query concurrently, so the lookups of a query arrive within a few
+// milliseconds of each other, and the batch is sent as soon as they all have.
+const graphQLBatchWait = 50 * time.Millisecond
+
+// graphQLLookup looks up a single node by key, e.g. a repository by its full
+// name. Lookups made concurrently are sent as one GraphQL query, with a field
+// aliased for each key, e.g. `r0: repository(...) r1: repository(...)`.
+type graphQLLookup struct {
+	// table is the table the lookup is logged as
+	table string
+	// column is the key column of the table, whose `in` list tells how many
+	// lookups to wait for
+	column string
+	// alias prefixes the alias of each key's field, e.g. "r" for r0, r1...
+	alias string
+	// field returns the field that selects the node of the key, and its
+	// variables, suffixed with i so they don't clash within a batch
+	field func(key string, i int) (string, map[string]interface{})
+	// model is the type the nodes are decoded into
+	model reflect.Type
+	// maxBatchSize keeps the queries under the node and complexity limits
+	// of the GraphQL API
+	maxBatchSize int
+}
+
+var repositoryLookup = &graphQLLookup{
+	table:  "github_repository",
+	column: "full_name",
+	alias:  "r",
+	field: func(key string, i int) (string, map[string]interface{}) {
+		owner, name := parseRepoFullName(key)
+		return fmt.Sprintf("repository(owner: $owner%[1]d, name: $name%[1]d)", i), map[string]interface{}{
+			fmt.Sprintf("owner%d", i): githubv4.String(owner),
+			fmt.Sprintf("name%d", i):  githubv4.String(name),
+		}
+	},
+	model:        reflect.TypeOf(models.Repository{}),
+	maxBatchSize: 20,
+}
+
+var userLookup = &graphQLLookup{
+	table:  "github_user",
+	column: "login",
+	alias:  "u",
+	field: func(key string, i int) (string, map[string]interface{}) {
+		return fmt.Sprintf("user(login: $login%d)", i), map[string]interface{}{
+			fmt.Sprintf("login%d", i): githubv4.String(key),
+		}
+	},
+	model:        reflect.TypeOf(models.UserWithCounts{}),
+	maxBatchSize: 50,
+}
+
+var organizationLookup = &graphQLLookup{
+	table:  "github_organization",
+	column: "login",
+	alias:  "o",
+	field: func(key string, i int) (string, map[string]interface{}) {
+		return fmt.Sprintf("organization(login: $login%d)", i), map[string]interface{}{
+			fmt.Sprintf("login%d", i): githubv4.String(key),
+		}
+	},
+	model:        reflect.TypeOf(models.OrganizationWithCounts{}),
+	maxBatchSize: 50,
+}
+
+// graphQLBatcher collects the pending lookups of a connection. The
+// variables shared by every field, e.g. the column includes, are part of
+// what identifies the batcher, so that a batch only has lookups for the same
+// columns.
+type graphQLBatcher struct {
+	key       string
+	lookup    *graphQLLookup
+	client    *githubv4.Client
+	variables map[string]interface{}
+
+	// pending is the batch lookups join, guarded by graphQLBatchersMu
+	pending *graphQLBatch
+}
+
+// graphQLBatch is a set of lookups sent as one query. The results and errors
+// are indexed like the keys, and are set once done is closed.
+type graphQLBatch struct {
+	ctx  context.Context
+	d    *plugin.QueryData
+	keys []string
+	// size is the number of keys the batch is sent with, unless it has
+	// waited for graphQLBatchWait
+	size int
+
+	once    sync.Once
+	done    chan struct{}
+	results []interface{}
+	errs    []error
+}
+
+// load returns the node of the key, or nil if the query didn't return one.
+// The variables are those shared by every field of the query.
+func (l *graphQLLookup) load(ctx context.Context, d *plugin.QueryData, variables map[string]interface{}, key string) (interface{}, error) {
+	q, err := getQueryCredential(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	graphQLBatchersMu.Lock()
+	b, err := getGraphQLBatcher(ctx, d, q, l, variables)
+	if err != nil {
+		graphQLBatchersMu.Unlock()
+		return nil, err
+	}
+	batch := b.pending
+	if batch == nil {
+		// The batch outlives the cancellation of the lookup that started it,
+		// as other lookups may be waiting on it
+		batch = &graphQLBatch{ctx: context.WithoutCancel(ctx), d: d, size: min(l.expectedLookups(d), l.maxBatchSize), done: make(chan struct{})}
+		b.pending = batch
+		time.AfterFunc(graphQLBatchWait, func() { b.send(batch) })
+	}
+	i := len(batch.keys)
+	batch.keys = append(batch.keys, key)
+	// A full batch stops taking lookups at once, rather than once it's sent,
+	// so that later lookups start a batch of their own
+	full := len(batch.keys) >= batch.size
+	if full {
+		b.pending = nil
+	}
+	graphQLBatchersMu.Unlock()
+
+	if full {
+		go b.send(batch)
+	}
+
+	select {
+	case <-batch.done:
+		return batch.results[i], batch.errs[i]
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// expectedLookups returns how many lookups the query makes at once, which is
+// the length of the `in` list of the key column, if it has one.
+func (l *graphQLLookup) expectedLookups(d *plugin.QueryData) int {
+	expected := 1
+	if quals, ok := d.QueryContext.UnsafeQuals[l.column]; ok {
+		for _, q := range quals.Quals {
+			if values := q.GetValue().GetListValue(); values != nil && q.GetStringValue() == "=" {
+				expected = max(expected, len(values.Values))
+			}
+		}
+	}
+	return expected
+}
+
+// getGraphQLBatcher returns the batcher of the lookups made by the
+// connection with the query's credential and shared variables, creating it
+// if no lookups are pending. It must be called with graphQLBatchersMu held.
+func getGraphQLBatcher(ctx context.Context, d *plugin.QueryData, q *queryCredential, l *graphQLLookup, variables map[string]interface{}) (*graphQLBatcher, error) {
+	var names []string
+	for name, value := range variables {
+		names = append(names, fmt.Sprintf("%s=%v", name, value))
+	}
+	sort.Strings(names)
+	key := fmt.Sprintf("%s_%s_%s_%s", d.Connection.Name, q.current().name, l.table, strings.Join(names, ","))
+
+	if b, ok := graphQLBatchers[key]; ok {
+		return b, nil
+	}
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	b := &graphQLBatcher{key: key, lookup: l, client: client, variables: variables}
+	graphQLBatchers[key] = b
+	return b, nil
+}
+
+// graphQLBatchers are the batchers with pending lookups. They aren't kept in
+// the connection cache, as a value set there isn't visible to concurrent
+// lookups straight away. A batcher is removed once its batch is sent, so
+// none outlives the lookups of its connection, and the next lookup after a
+// config change gets a client for the new config.
+var (
+	graphQLBatchersMu sync.Mutex
+	graphQLBatchers   = map[string]*graphQLBatcher{}
+)
+
+// send sends the batch once, when it is full or has waited long enough.
+func (b *graphQLBatcher) send(batch *graphQLBatch) {
+	batch.once.Do(func() {
+		graphQLBatchersMu.Lock()
+		if b.pending == batch {
+			b.pending = nil
+		}
+		// A batcher may have a batch pending that was started after this one
+		// was full
+		if graphQLBatchers[b.key] == b && b.pending == nil {
+			delete(graphQLBatchers, b.key)
+		}
+		graphQLBatchersMu.Unlock()
+
+		batch.results, batch.errs = make([]interface{}, len(batch.keys)), make([]error, len(batch.keys))
+		results, err := b.query(batch.ctx, batch.d, batch.keys)
+		copy(batch.results, results)
+		for i, r := range batch.results {
+			if r != nil || err == nil {
+				continue
+			}
+			// The errors of a GraphQL response don't say which field they
+			// are for, so a key without a node is looked up alone to get its
+			// own error, e.g. that the repository doesn't exist
+			if len(batch.keys) == 1 {
+				batch.errs[i] = err
+				continue
+			}
+			single, err := b.query(batch.ctx, batch.d, batch.keys[i:i+1])
+			batch.results[i], batch.errs[i] = single[0], err
+		}
+		close(batch.done)
+	})
+}
+
+// query sends a query with an aliased field for each key, and returns the
+// nodes in the order of the keys. Keys that didn't resolve to a node have a
+// nil result.
+func (b *graphQLBatcher) query(ctx context.Context, d *plugin.QueryData, keys []string) ([]interface{}, error) {
+	l := b.lookup
+
+	// The fields are sorted by key, so that the same keys always make the
+	// same query
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return keys[order[i]] < keys[order[j]] })
+
+	fields := []reflect.StructField{{Name: "RateLimit", Type: reflect.TypeOf(models.RateLimit{})}}
+	variables := map[string]interface{}{}
+	for name, value := range b.variables {
+		variables[name] = value
+	}
+	for i, k := range order {
+		field, fieldVariables := l.field(keys[k], i)
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Node%d", i),
+			Type: reflect.PointerTo(l.model),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"%s%d: %s"`, l.alias, i, field)),
+		})
+		for name, value := range fieldVariables {
+			variables[name] = value
+		}
+	}
+
+	query := reflect.New(reflect.StructOf(fields))
+	err := b.client.Query(ctx, query.Interface(), variables)
+	rateLimit := query.Elem().Field(0).Interface().(models.RateLimit)
+	logRateLimit(ctx, d, l.table, &rateLimit)
+	if err != nil {
+		plugin.Logger(ctx).Error(l.table, "api_error", err, "batch_size", len(keys))
+	}
+
+	results := make([]interface{}, len(keys))
+	for i, k := range order {
+		if node := query.Elem().Field(i + 1); !node.IsNil() {
+			results[k] = node.Elem().Interface()
+		}
+	}
+	return results, err
+}
@@ -0,0 +1,306 @@
+package github
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+)
+
+const (
+	// defaultHTTPCacheMaxSize is the size cap of the cache when
+	// http_cache_max_size_mb is not set.
+	defaultHTTPCacheMaxSize = 100 << 20
+	// defaultHTTPCacheTTL is how long an entry is revalidated for when
+	// http_cache_ttl is not set.
+	defaultHTTPCacheTTL = 24 * time.Hour
+)
+
+// httpCache stores REST responses on disk, along with their ETag and
+// Last-Modified validators, so that requests for them can be made
+// conditional. GitHub doesn't count a 304 Not Modified response against the
+// rate limit, so polling a resource that hasn't changed is free.
+type httpCache struct {
+	dir     string
+	maxSize int64
+	ttl     time.Duration
+
+	// opened creates the directory on first use, so that loading the
+	// connection config has no side effects
+	opened  sync.Once
+	openErr error
+
+	mu   sync.Mutex
+	size int64
+}
+
+// httpCacheEntry is a cached response, as stored on disk. The modification
+// time of the file is when the entry was last stored or revalidated.
+type httpCacheEntry struct {
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// newHTTPCache returns a cache stored in dir. The directory is not touched
+// until the cache is first used.
+func newHTTPCache(dir string, maxSize int64, ttl time.Duration) *httpCache {
+	return &httpCache{dir: dir, maxSize: maxSize, ttl: ttl}
+}
+
+// open creates the cache directory if needed, and removes the expired entries
+// left in it by earlier runs, the first time the cache is used.
+func (c *httpCache) open() error {
+	c.opened.Do(func() {
+		if c.openErr = os.MkdirAll(c.dir, 0700); c.openErr != nil {
+			return
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.evict(c.maxSize)
+	})
+	return c.openErr
+}
+
+// defaultHTTPCacheDir returns the directory of the cache when http_cache_dir
+// is not set.
+func defaultHTTPCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "steampipe-plugin-github", "http"), nil
+}
+
+// transport returns a transport that sends requests with base, and caches the
+// responses under the credential, which identifies the token the requests are
+// authenticated with. Responses are never shared across credentials, as they
+// may see different data. A nil cache returns base as is.
+func (c *httpCache) transport(base http.RoundTripper, credential string) http.RoundTripper {
+	if c == nil {
+		return base
+	}
+	return &httpCacheTransport{cache: c, base: base, credential: credential}
+}
+
+// httpCacheTransport is an http.RoundTripper that makes GET requests
+// conditional on the cached response being unchanged, and replays the cached
+// response when GitHub answers 304 Not Modified.
+type httpCacheTransport struct {
+	cache      *httpCache
+	base       http.RoundTripper
+	credential string
+}
+
+func (t *httpCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests that are already conditional, or are for a range, are left
+	// for the caller to handle
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" || req.Header.Get("Range") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	// Requests are sent uncached if the cache directory can't be created
+	if err := t.cache.open(); err != nil {
+		plugin.Logger(req.Context()).Warn("httpCacheTransport.RoundTrip", "cache_dir_error", err, "dir", t.cache.dir)
+		return t.base.RoundTrip(req)
+	}
+
+	key := t.key(req)
+	entry := t.cache.load(key)
+	if entry != nil {
+		clone := req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			clone.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+			clone.Header.Set("If-Modified-Since", lastModified)
+		}
+		req = clone
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		plugin.Logger(req.Context()).Trace("httpCacheTransport.RoundTrip", "not_modified", req.URL.Path)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		t.cache.touch(key)
+
+		// The 304 carries the current rate limit headers, which the
+		// credential pool reads
+		header := entry.Header.Clone()
+		for k, v := range resp.Header {
+			header[k] = v
+		}
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+			ContentLength: int64(len(entry.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK || (resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") || strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	header.Del("Content-Length")
+	header.Del("Content-Encoding")
+	header.Del("Transfer-Encoding")
+	if err := t.cache.store(key, &httpCacheEntry{URL: req.URL.String(), Header: header, Body: body}); err != nil {
+		plugin.Logger(req.Context()).Warn("httpCacheTransport.RoundTrip", "store_error", err, "url", req.URL.Path)
+	}
+	return resp, nil
+}
+
+// key identifies the response to the request for the credential. The Accept
+// header is part of the key, as GitHub serves different media types from the
+// same URL.
+func (t *httpCacheTransport) key(req *http.Request) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s", t.credential, req.URL.String(), req.Header.Get("Accept"), req.Header.Get("X-GitHub-Api-Version"))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *httpCache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
+
+// load returns the entry stored under the key, or nil if there is none or it
+// has expired.
+func (c *httpCache) load(key string) *httpCacheEntry {
+	f, err := os.Open(c.path(key))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || time.Since(info.ModTime()) > c.ttl {
+		return nil
+	}
+	var entry httpCacheEntry
+	if err := gob.NewDecoder(f).Decode(&entry); err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// touch marks the entry as revalidated, which restarts its TTL and keeps it
+// from being evicted ahead of entries that are no longer used.
+func (c *httpCache) touch(key string) {
+	now := time.Now()
+	os.Chtimes(c.path(key), now, now)
+}
+
+// store writes the entry under the key, evicting the least recently used
+// entries if the cache grows past its size cap. The entry is written to a
+// temporary file first, so that concurrent readers never see a partial
+// entry.
+func (c *httpCache) store(key string, entry *httpCacheEntry) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entry); err != nil {
+		return err
+	}
+	if int64(buf.Len()) > c.maxSize {
+		return nil
+	}
+
+	f, err := os.CreateTemp(c.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var replaced int64
+	if info, err := os.Stat(c.path(key)); err == nil {
+		replaced = info.Size()
+	}
+	if err := os.Rename(f.Name(), c.path(key)); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	c.size += int64(buf.Len()) - replaced
+	if c.size > c.maxSize {
+		// Evict down to below the cap, so that eviction doesn't run on every
+		// store once the cache is full
+		c.evict(c.maxSize * 9 / 10)
+	}
+	return nil
+}
+
+// evict removes the expired entries, and then the least recently used ones
+// until the cache is no larger than target. The directory is scanned rather
+// than tracked in memory, as it may be shared by other connections and
+// processes. It must be called with mu held.
+func (c *httpCache) evict(target int64) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+
+	var files []os.FileInfo
+	var size int64
+	for _, e := range dirEntries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if time.Since(info.ModTime()) > c.ttl {
+			os.Remove(filepath.Join(c.dir, info.Name()))
+			continue
+		}
+		files = append(files, info)
+		size += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, info := range files {
+		if size <= target {
+			break
+		}
+		if os.Remove(filepath.Join(c.dir, info.Name())) == nil {
+			size -= info.Size()
+		}
+	}
+	c.size = size
+}
@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/context_key"
+)
+
+// newETagServer returns a server that serves a body of the given size with
+// an ETag per path, and answers 304 Not Modified when the ETag matches.
+func newETagServer(t *testing.T, size int) (*httptest.Server, *int32) {
+	var notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf("%q", r.URL.Path)
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if r.Header.Get("If-None-Match") == etag {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Link", `<next>; rel="next"`)
+		body := make([]byte, size)
+		for i := range body {
+			body[i] = 'a' + byte(i%26)
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &notModified
+}
+
+func getBody(t *testing.T, transport http.RoundTripper, url string) (*http.Response, string) {
+	t.Helper()
+	ctx := context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(body)
+}
+
+func TestHTTPCacheReplaysNotModified(t *testing.T) {
+	server, notModified := newETagServer(t, 64)
+	cache := newHTTPCache(t.TempDir(), defaultHTTPCacheMaxSize, time.Hour)
+	transport := cache.transport(http.DefaultTransport, "token a")
+
+	_, first := getBody(t, transport, server.URL+"/repos/o/r")
+	resp, second := getBody(t, transport, server.URL+"/repos/o/r")
+	if *notModified != 1 {
+		t.Fatalf("expected the second request to be answered 304, got %d", *notModified)
+	}
+	if resp.StatusCode != http.StatusOK || second != first {
+		t.Errorf("expected the cached body with status 200, got %d %q", resp.StatusCode, second)
+	}
+	if resp.Header.Get("Link") == "" || resp.Header.Get("X-RateLimit-Remaining") != "4999" {
+		t.Errorf("expected the cached headers merged with those of the 304, got %v", resp.Header)
+	}
+
+	// Another credential doesn't see the cached response
+	getBody(t, cache.transport(http.DefaultTransport, "token b"), server.URL+"/repos/o/r")
+	if *notModified != 1 {
+		t.Errorf("expected a cache miss for another credential, got %d 304s", *notModified)
+	}
+}
+
+func TestHTTPCacheTTL(t *testing.T) {
+	server, notModified := newETagServer(t, 64)
+	dir := t.TempDir()
+	cache := newHTTPCache(dir, defaultHTTPCacheMaxSize, time.Minute)
+	transport := cache.transport(http.DefaultTransport, "token a")
+	getBody(t, transport, server.URL+"/repos/o/r")
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 cache entry, got %d", len(entries))
+	}
+	expired := time.Now().Add(-2 * time.Minute)
+	os.Chtimes(filepath.Join(dir, entries[0].Name()), expired, expired)
+
+	getBody(t, transport, server.URL+"/repos/o/r")
+	if *notModified != 0 {
+		t.Errorf("expected an expired entry not to be revalidated, got %d 304s", *notModified)
+	}
+}
+
+func TestHTTPCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	server, notModified := newETagServer(t, 1000)
+	dir := t.TempDir()
+	cache := newHTTPCache(dir, 3000, time.Hour)
+	transport := cache.transport(http.DefaultTransport, "token a")
+
+	for i := 0; i < 3; i++ {
+		getBody(t, transport, fmt.Sprintf("%s/repos/o/r%d", server.URL, i))
+		// Keep the modification times of the entries apart
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			info, _ := e.Info()
+			past := info.ModTime().Add(-time.Second)
+			os.Chtimes(filepath.Join(dir, e.Name()), past, past)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Fatalf("expected the oldest entry to be evicted, got %d entries", len(entries))
+	}
+	getBody(t, transport, server.URL+"/repos/o/r0")
+	getBody(t, transport, server.URL+"/repos/o/r2")
+	if *notModified != 1 {
+		t.Errorf("expected only the newest entry to be revalidated, got %d 304s", *notModified)
+	}
+}
@@ -2,12 +2,15 @@ package github
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
+	"sort"
 
 	"github.com/shurcooL/githubv4"
 	"github.com/turbot/steampipe-plugin-github/github/models"
 	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/quals"
 )
 
 func extractPRReviewFromHydrateItem(h *plugin.HydrateData) (models.PullRequestReview, error) {
@@ -123,7 +126,7 @@ func appendIssueColumnIncludes(m *map[string]interface{}, cols []string) {
 	(*m)["includeIssueAuthor"] = githubv4.Boolean(slices.Contains(cols, "author") || slices.Contains(cols, "author_login"))
 	(*m)["includeIssueBody"] = githubv4.Boolean(slices.Contains(cols, "body"))
 	(*m)["includeIssueEditor"] = githubv4.Boolean(slices.Contains(cols, "editor"))
-	(*m)["includeIssueMilestone"] = githubv4.Boolean(slices.Contains(cols, "milestone"))
+	(*m)["includeIssueMilestone"] = githubv4.Boolean(slices.Contains(cols, "milestone") || slices.Contains(cols, "milestone_number"))
 	(*m)["includeIssueViewer"] = githubv4.Boolean(slices.Contains(cols, "user_can_close") ||
 		slices.Contains(cols, "user_can_react") ||
 		slices.Contains(cols, "user_can_reopen") ||
@@ -1294,3 +1297,33 @@ func prHydrateSubscription(_ context.Context, _ *plugin.QueryData, h *plugin.Hyd
 	}
 	return pr.Subscription, nil
 }
+
+// labelFilter returns the label to filter issues or pull requests by for the
+// quals on the labels column, or "" if none can be. GitHub lists those with
+// any of the labels it's given, so quals needing several labels are narrowed
+// to the first of them, and Steampipe checks the rest.
+func labelFilter(qs []*quals.Qual) string {
+	for _, q := range qs {
+		switch q.Operator {
+		case "?":
+			return q.Value.GetStringValue()
+		case "@>":
+			// The labels column maps the name of each label to true
+			var labels map[string]bool
+			if err := json.Unmarshal([]byte(q.Value.GetJsonbValue()), &labels); err != nil {
+				continue
+			}
+			var names []string
+			for name, set := range labels {
+				if set {
+					names = append(names, name)
+				}
+			}
+			if len(names) > 0 {
+				sort.Strings(names)
+				return names[0]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshKeyFingerprint returns the SHA256 fingerprint of an SSH public key in
+// the authorized_keys format, e.g. SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8.
+func sshKeyFingerprint(_ context.Context, input *transform.TransformData) (interface{}, error) {
+	key, err := parseSSHKey(input.Value)
+	if key == nil || err != nil {
+		return nil, err
+	}
+	return ssh.FingerprintSHA256(key), nil
+}
+
+// sshKeyType returns the type of an SSH public key in the authorized_keys
+// format, e.g. ssh-ed25519.
+func sshKeyType(_ context.Context, input *transform.TransformData) (interface{}, error) {
+	key, err := parseSSHKey(input.Value)
+	if key == nil || err != nil {
+		return nil, err
+	}
+	return key.Type(), nil
+}
+
+func parseSSHKey(value interface{}) (ssh.PublicKey, error) {
+	var authorizedKey string
+	switch v := value.(type) {
+	case string:
+		authorizedKey = v
+	case *string:
+		if v != nil {
+			authorizedKey = *v
+		}
+	}
+	if authorizedKey == "" {
+		return nil, nil
+	}
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH public key: %v", err)
+	}
+	return key, nil
+}
+
+// gpgKeyFingerprint returns the fingerprint of an OpenPGP public key, as
+// returned in the public_key field of a GPG key, which is the base64 encoded
+// public key or subkey packet.
+func gpgKeyFingerprint(_ context.Context, input *transform.TransformData) (interface{}, error) {
+	var publicKey string
+	switch v := input.Value.(type) {
+	case string:
+		publicKey = v
+	case *string:
+		if v != nil {
+			publicKey = *v
+		}
+	}
+	if publicKey == "" {
+		return nil, nil
+	}
+	return computeGPGKeyFingerprint(publicKey)
+}
+
+// computeGPGKeyFingerprint computes the fingerprint of a base64 encoded
+// public key packet, as defined by RFC 4880 for version 4 keys and RFC 9580
+// for version 6 keys.
+func computeGPGKeyFingerprint(publicKey string) (string, error) {
+	packet, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode GPG public key: %v", err)
+	}
+	body, err := gpgPacketBody(packet)
+	if err != nil {
+		return "", err
+	}
+
+	switch body[0] {
+	case 4:
+		h := sha1.New()
+		h.Write([]byte{0x99, byte(len(body) >> 8), byte(len(body))})
+		h.Write(body)
+		return strings.ToUpper(fmt.Sprintf("%x", h.Sum(nil))), nil
+	case 6:
+		h := sha256.New()
+		h.Write([]byte{0x9b})
+		binary.Write(h, binary.BigEndian, uint32(len(body)))
+		h.Write(body)
+		return strings.ToUpper(fmt.Sprintf("%x", h.Sum(nil))), nil
+	}
+	return "", fmt.Errorf("unsupported GPG public key version %d", body[0])
+}
+
+// gpgPacketBody returns the body of a public key or subkey packet, in either
+// the old or the new packet format.
+func gpgPacketBody(packet []byte) ([]byte, error) {
+	if len(packet) < 2 || packet[0]&0x80 == 0 {
+		return nil, fmt.Errorf("invalid GPG public key packet")
+	}
+
+	var tag byte
+	var length, offset int
+	if packet[0]&0x40 != 0 {
+		tag = packet[0] & 0x3f
+		switch l := int(packet[1]); {
+		case l < 192:
+			length, offset = l, 2
+		case l < 224 && len(packet) >= 3:
+			length, offset = (l-192)<<8+int(packet[2])+192, 3
+		case l == 255 && len(packet) >= 6:
+			length, offset = int(binary.BigEndian.Uint32(packet[2:6])), 6
+		default:
+			return nil, fmt.Errorf("invalid GPG public key packet length")
+		}
+	} else {
+		tag = (packet[0] >> 2) & 0x0f
+		switch packet[0] & 0x03 {
+		case 0:
+			length, offset = int(packet[1]), 2
+		case 1:
+			if len(packet) < 3 {
+				return nil, fmt.Errorf("invalid GPG public key packet length")
+			}
+			length, offset = int(binary.BigEndian.Uint16(packet[1:3])), 3
+		case 2:
+			if len(packet) < 5 {
+				return nil, fmt.Errorf("invalid GPG public key packet length")
+			}
+			length, offset = int(binary.BigEndian.Uint32(packet[1:5])), 5
+		default:
+			length, offset = len(packet)-1, 1
+		}
+	}
+
+	// Tag 6 is a public key packet, and tag 14 a public subkey packet
+	if tag != 6 && tag != 14 {
+		return nil, fmt.Errorf("unexpected GPG packet tag %d", tag)
+	}
+	if length == 0 || offset+length > len(packet) {
+		return nil, fmt.Errorf("truncated GPG public key packet")
+	}
+	return packet[offset : offset+length], nil
+}
+
+// gpgSubkeys returns the subkeys of a GPG key, with their fingerprints.
+func gpgSubkeys(_ context.Context, input *transform.TransformData) (interface{}, error) {
+	subkeys, ok := input.Value.([]*github.GPGKey)
+	if !ok || len(subkeys) == 0 {
+		return nil, nil
+	}
+
+	var result []map[string]interface{}
+	for _, s := range subkeys {
+		subkey := map[string]interface{}{
+			"id":                  s.GetID(),
+			"key_id":              s.GetKeyID(),
+			"can_sign":            s.GetCanSign(),
+			"can_certify":         s.GetCanCertify(),
+			"can_encrypt_comms":   s.GetCanEncryptComms(),
+			"can_encrypt_storage": s.GetCanEncryptStorage(),
+			"created_at":          s.CreatedAt,
+			"expires_at":          s.ExpiresAt,
+		}
+		if s.GetPublicKey() != "" {
+			fingerprint, err := computeGPGKeyFingerprint(s.GetPublicKey())
+			if err != nil {
+				return nil, err
+			}
+			subkey["fingerprint"] = fingerprint
+		}
+		result = append(result, subkey)
+	}
+	return result, nil
+}
+
+// keyOwner returns the login of the user whose keys are listed, which is the
+// login qualifier or else the authenticated user, and the user to pass to the
+// API, which is empty for the authenticated user. The authenticated user is
+// only looked up when the login qualifier is not set, as installation tokens
+// have no user. Their keys are listed from their own endpoint, which returns
+// more of their fields than the public one.
+func keyOwner(ctx context.Context, d *plugin.QueryData, client *github.Client) (string, string, error) {
+	if login := d.EqualsQualString("login"); login != "" {
+		return login, login, nil
+	}
+
+	// Load the authenticated user's login from cache
+	cacheKey, err := viewerCacheKey(ctx, d, "github_viewer_login")
+	if err != nil {
+		return "", "", err
+	}
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(string), "", nil
+	}
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return "", "", err
+	}
+	viewer := user.GetLogin()
+	d.ConnectionManager.Cache.Set(cacheKey, viewer)
+
+	return viewer, "", nil
+}
@@ -22,78 +22,129 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 		},
 		DefaultTransform:   transform.FromGo(),
 		DefaultRetryConfig: retryConfig(),
-		TableMap: map[string]*plugin.Table{
-			"github_actions_artifact":                tableGitHubActionsArtifact(),
-			"github_actions_environment_variable":    tableGitHubActionsEnvironmentVariable(),
-			"github_actions_organization_variable":   tableGitHubActionsOrganizationVariable(),
-			"github_actions_repository_runner":       tableGitHubActionsRepositoryRunner(),
-			"github_actions_repository_secret":       tableGitHubActionsRepositorySecret(),
-			"github_actions_repository_variable":     tableGitHubActionsRepositoryVariable(),
-			"github_actions_repository_workflow_job": tableGitHubActionsRepositoryWorkflowJob(),
-			"github_actions_repository_workflow_run": tableGitHubActionsRepositoryWorkflowRun(),
-			"github_audit_log":                       tableGitHubAuditLog(),
-			"github_blob":                            tableGitHubBlob(),
-			"github_branch":                          tableGitHubBranch(),
-			"github_branch_protection":               tableGitHubBranchProtection(),
-			"github_code_owner":                      tableGitHubCodeOwner(),
-			"github_commit":                          tableGitHubCommit(),
-			"github_community_profile":               tableGitHubCommunityProfile(),
-			"github_gist":                            tableGitHubGist(),
-			"github_gitignore":                       tableGitHubGitignore(),
-			"github_issue":                           tableGitHubIssue(),
-			"github_issue_comment":                   tableGitHubIssueComment(),
-			"github_license":                         tableGitHubLicense(),
-			"github_my_gist":                         tableGitHubMyGist(),
-			"github_my_issue":                        tableGitHubMyIssue(),
-			"github_my_organization":                 tableGitHubMyOrganization(),
-			"github_my_repository":                   tableGitHubMyRepository(),
-			"github_my_star":                         tableGitHubMyStar(),
-			"github_my_team":                         tableGitHubMyTeam(),
-			"github_organization":                    tableGitHubOrganization(),
-			"github_organization_dependabot_alert":   tableGitHubOrganizationDependabotAlert(),
-			"github_organization_external_identity":  tableGitHubOrganizationExternalIdentity(),
-			"github_organization_member":             tableGitHubOrganizationMember(),
-			"github_organization_collaborator":       tableGitHubOrganizationCollaborator(),
-			"github_organization_ruleset":            tableGitHubOrganizationRuleset(),
-			"github_package":                         tableGitHubPackage(),
-			"github_package_version":                 tableGitHubPackageVersion(),
-			"github_pull_request":                    tableGitHubPullRequest(),
-			"github_pull_request_comment":            tableGitHubPullRequestComment(),
-			"github_pull_request_review":             tableGitHubPullRequestReview(),
-			"github_rate_limit":                      tableGitHubRateLimit(),
-			"github_rate_limit_graphql":              tableGitHubRateLimitGraphQL(),
-			"github_release":                         tableGitHubRelease(),
-			"github_repository":                      tableGitHubRepository(),
-			"github_repository_collaborator":         tableGitHubRepositoryCollaborator(),
-			"github_repository_content":              tableGitHubRepositoryContent(),
-			"github_repository_dependabot_alert":     tableGitHubRepositoryDependabotAlert(),
-			"github_repository_deployment":           tableGitHubRepositoryDeployment(),
-			"github_repository_discussion":           tableGitHubRepositoryDiscussion(),
-			"github_repository_environment":          tableGitHubRepositoryEnvironment(),
-			"github_repository_ruleset":              tableGitHubRepositoryRuleset(),
-			"github_repository_sbom":                 tableGitHubRepositorySbom(),
-			"github_repository_vulnerability_alert":  tableGitHubRepositoryVulnerabilityAlert(),
-			"github_search_code":                     tableGitHubSearchCode(),
-			"github_search_commit":                   tableGitHubSearchCommit(),
-			"github_search_issue":                    tableGitHubSearchIssue(),
-			"github_search_label":                    tableGitHubSearchLabel(),
-			"github_search_pull_request":             tableGitHubSearchPullRequest(),
-			"github_search_repository":               tableGitHubSearchRepository(),
-			"github_search_topic":                    tableGitHubSearchTopic(),
-			"github_search_user":                     tableGitHubSearchUser(),
-			"github_stargazer":                       tableGitHubStargazer(),
-			"github_tag":                             tableGitHubTag(),
-			"github_team":                            tableGitHubTeam(),
-			"github_team_member":                     tableGitHubTeamMember(),
-			"github_team_repository":                 tableGitHubTeamRepository(),
-			"github_traffic_clone_daily":             tableGitHubTrafficCloneDaily(),
-			"github_traffic_clone_weekly":            tableGitHubTrafficCloneWeekly(),
-			"github_traffic_view_daily":              tableGitHubTrafficViewDaily(),
-			"github_traffic_view_weekly":             tableGitHubTrafficViewWeekly(),
-			"github_tree":                            tableGitHubTree(),
-			"github_user":                            tableGitHubUser(),
-			"github_workflow":                        tableGitHubWorkflow(),
-		},
+		// The tables only differ between connections in whether the
+		// repository scoped tables fan out, which relaxes their
+		// repository_full_name key column
+		SchemaMode:   plugin.SchemaModeDynamic,
+		TableMapFunc: tableMap,
 	}
 	return p
 }
+
+// tableMap returns the tables of a connection, built anew for each as the
+// plugin initialises them for the connection.
+func tableMap(_ context.Context, d *plugin.TableMapData) (map[string]*plugin.Table, error) {
+	tables := map[string]*plugin.Table{
+		"github_actions_artifact":                   tableGitHubActionsArtifact(),
+		"github_actions_enterprise_runner":          tableGitHubActionsEnterpriseRunner(),
+		"github_actions_enterprise_runner_group":    tableGitHubActionsEnterpriseRunnerGroup(),
+		"github_actions_environment_secret":         tableGitHubActionsEnvironmentSecret(),
+		"github_actions_environment_variable":       tableGitHubActionsEnvironmentVariable(),
+		"github_actions_organization_permissions":   tableGitHubActionsOrganizationPermissions(),
+		"github_actions_organization_runner":        tableGitHubActionsOrganizationRunner(),
+		"github_actions_organization_secret":        tableGitHubActionsOrganizationSecret(),
+		"github_actions_organization_variable":      tableGitHubActionsOrganizationVariable(),
+		"github_actions_repository_permissions":     tableGitHubActionsRepositoryPermissions(),
+		"github_actions_repository_runner":          tableGitHubActionsRepositoryRunner(),
+		"github_actions_repository_secret":          tableGitHubActionsRepositorySecret(),
+		"github_actions_repository_variable":        tableGitHubActionsRepositoryVariable(),
+		"github_actions_repository_workflow_job":    tableGitHubActionsRepositoryWorkflowJob(),
+		"github_actions_repository_workflow_run":    tableGitHubActionsRepositoryWorkflowRun(),
+		"github_actions_runner_group":               tableGitHubActionsRunnerGroup(),
+		"github_actions_runner_group_repository":    tableGitHubActionsRunnerGroupRepository(),
+		"github_actions_workflow_job_log":           tableGitHubActionsWorkflowJobLog(),
+		"github_app_installation":                   tableGitHubAppInstallation(),
+		"github_audit_log":                          tableGitHubAuditLog(),
+		"github_blob":                               tableGitHubBlob(),
+		"github_branch":                             tableGitHubBranch(),
+		"github_branch_protection":                  tableGitHubBranchProtection(),
+		"github_check_run":                          tableGitHubCheckRun(),
+		"github_check_run_annotation":               tableGitHubCheckRunAnnotation(),
+		"github_check_suite":                        tableGitHubCheckSuite(),
+		"github_code_owner":                         tableGitHubCodeOwner(),
+		"github_code_scanning_analysis":             tableGitHubCodeScanningAnalysis(),
+		"github_codespaces_organization_secret":     tableGitHubCodespacesOrganizationSecret(),
+		"github_commit":                             tableGitHubCommit(),
+		"github_commit_status":                      tableGitHubCommitStatus(),
+		"github_community_profile":                  tableGitHubCommunityProfile(),
+		"github_dependabot_organization_secret":     tableGitHubDependabotOrganizationSecret(),
+		"github_dependabot_repository_secret":       tableGitHubDependabotRepositorySecret(),
+		"github_gist":                               tableGitHubGist(),
+		"github_gitignore":                          tableGitHubGitignore(),
+		"github_issue":                              tableGitHubIssue(),
+		"github_issue_comment":                      tableGitHubIssueComment(),
+		"github_license":                            tableGitHubLicense(),
+		"github_my_gist":                            tableGitHubMyGist(),
+		"github_my_issue":                           tableGitHubMyIssue(),
+		"github_my_organization":                    tableGitHubMyOrganization(),
+		"github_my_repository":                      tableGitHubMyRepository(),
+		"github_my_star":                            tableGitHubMyStar(),
+		"github_my_team":                            tableGitHubMyTeam(),
+		"github_organization":                       tableGitHubOrganization(),
+		"github_organization_code_scanning_alert":   tableGitHubOrganizationCodeScanningAlert(),
+		"github_organization_dependabot_alert":      tableGitHubOrganizationDependabotAlert(),
+		"github_organization_external_identity":     tableGitHubOrganizationExternalIdentity(),
+		"github_organization_member":                tableGitHubOrganizationMember(),
+		"github_organization_collaborator":          tableGitHubOrganizationCollaborator(),
+		"github_organization_ruleset":               tableGitHubOrganizationRuleset(),
+		"github_organization_secret_repository":     tableGitHubOrganizationSecretRepository(),
+		"github_organization_secret_scanning_alert": tableGitHubOrganizationSecretScanningAlert(),
+		"github_organization_webhook":               tableGitHubOrganizationWebhook(),
+		"github_package":                            tableGitHubPackage(),
+		"github_package_version":                    tableGitHubPackageVersion(),
+		"github_pull_request":                       tableGitHubPullRequest(),
+		"github_pull_request_comment":               tableGitHubPullRequestComment(),
+		"github_pull_request_diff_hunk":             tableGitHubPullRequestDiffHunk(),
+		"github_pull_request_file":                  tableGitHubPullRequestFile(),
+		"github_pull_request_review":                tableGitHubPullRequestReview(),
+		"github_rate_limit":                         tableGitHubRateLimit(),
+		"github_rate_limit_graphql":                 tableGitHubRateLimitGraphQL(),
+		"github_release":                            tableGitHubRelease(),
+		"github_repository":                         tableGitHubRepository(),
+		"github_repository_code_scanning_alert":     tableGitHubRepositoryCodeScanningAlert(),
+		"github_repository_collaborator":            tableGitHubRepositoryCollaborator(),
+		"github_repository_content":                 tableGitHubRepositoryContent(),
+		"github_repository_dependabot_alert":        tableGitHubRepositoryDependabotAlert(),
+		"github_repository_deploy_key":              tableGitHubRepositoryDeployKey(),
+		"github_repository_deployment":              tableGitHubRepositoryDeployment(),
+		"github_repository_discussion":              tableGitHubRepositoryDiscussion(),
+		"github_repository_environment":             tableGitHubRepositoryEnvironment(),
+		"github_repository_ruleset":                 tableGitHubRepositoryRuleset(),
+		"github_repository_sbom":                    tableGitHubRepositorySbom(),
+		"github_repository_secret_scanning_alert":   tableGitHubRepositorySecretScanningAlert(),
+		"github_repository_vulnerability_alert":     tableGitHubRepositoryVulnerabilityAlert(),
+		"github_repository_webhook":                 tableGitHubRepositoryWebhook(),
+		"github_search_code":                        tableGitHubSearchCode(),
+		"github_search_commit":                      tableGitHubSearchCommit(),
+		"github_search_issue":                       tableGitHubSearchIssue(),
+		"github_search_label":                       tableGitHubSearchLabel(),
+		"github_search_pull_request":                tableGitHubSearchPullRequest(),
+		"github_search_repository":                  tableGitHubSearchRepository(),
+		"github_search_topic":                       tableGitHubSearchTopic(),
+		"github_search_user":                        tableGitHubSearchUser(),
+		"github_secret_scanning_alert_location":     tableGitHubSecretScanningAlertLocation(),
+		"github_stargazer":                          tableGitHubStargazer(),
+		"github_tag":                                tableGitHubTag(),
+		"github_team":                               tableGitHubTeam(),
+		"github_team_member":                        tableGitHubTeamMember(),
+		"github_team_repository":                    tableGitHubTeamRepository(),
+		"github_token_permission":                   tableGitHubTokenPermission(),
+		"github_traffic_clone_daily":                tableGitHubTrafficCloneDaily(),
+		"github_traffic_clone_weekly":               tableGitHubTrafficCloneWeekly(),
+		"github_traffic_view_daily":                 tableGitHubTrafficViewDaily(),
+		"github_traffic_view_weekly":                tableGitHubTrafficViewWeekly(),
+		"github_tree":                               tableGitHubTree(),
+		"github_user":                               tableGitHubUser(),
+		"github_user_gpg_key":                       tableGitHubUserGPGKey(),
+		"github_user_ssh_key":                       tableGitHubUserSSHKey(),
+		"github_user_ssh_signing_key":               tableGitHubUserSSHSigningKey(),
+		"github_webhook_delivery":                   tableGitHubWebhookDelivery(),
+		"github_workflow":                           tableGitHubWorkflow(),
+	}
+	if GetConfig(d.Connection).fanOut() {
+		for _, table := range tables {
+			withRepositoryFanOut(table)
+		}
+	}
+	return tables, nil
+}
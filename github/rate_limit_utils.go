@@ -10,6 +10,15 @@ import (
 	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
 )
 
+// logRateLimit logs the rate limit reported by a GraphQL query, and records
+// it in the rate limit budget used to throttle further requests.
+func logRateLimit(ctx context.Context, d *plugin.QueryData, table string, rateLimits *models.RateLimit) {
+	plugin.Logger(ctx).Debug(rateLimitLogString(table, rateLimits))
+	if q, err := getQueryCredential(ctx, d); err == nil {
+		q.current().observeRateLimit("graphql", rateLimits)
+	}
+}
+
 func extractRateLimitFromHydrateItem(h *plugin.HydrateData) (models.BaseRateLimit, error) {
 	if rl, ok := h.Item.(models.BaseRateLimit); ok {
 		return rl, nil
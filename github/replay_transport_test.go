@@ -0,0 +1,439 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/context_key"
+)
+
+// interaction is a single exchange with the GitHub API, as stored in a
+// fixture.
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+// recordedRequest identifies a request. Paths are relative to the API root,
+// so fixtures recorded against github.com replay against GitHub Enterprise
+// Server URLs and the other way round.
+type recordedRequest struct {
+	Method  string           `json:"method"`
+	Path    string           `json:"path"`
+	Query   string           `json:"query,omitempty"`
+	GraphQL *recordedGraphQL `json:"graphql,omitempty"`
+}
+
+// recordedGraphQL identifies a GraphQL request by its top level fields and
+// its variables, rather than by its query text, so that adding a field to a
+// model doesn't invalidate the fixtures. The variables of a fixture only need
+// to be a subset of those of the request, which keeps hand written fixtures
+// short.
+type recordedGraphQL struct {
+	Fields    []string               `json:"fields"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+
+	// query is the query text, reported for requests without a fixture
+	query string
+}
+
+type recordedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is a JSON response body, Text any other body
+	Body json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+// recordedHeaders are the response headers kept in fixtures. Everything else,
+// including anything that may identify the recording credential, is dropped.
+var recordedHeaders = []string{
+	"Content-Type",
+	"GitHub-Authentication-Token-Expiration",
+	"Link",
+	"Location",
+	"X-OAuth-Scopes",
+}
+
+// newRecordedRequest describes an http.Request the way it is matched against
+// fixtures. The request body is restored so the request can still be sent.
+func newRecordedRequest(req *http.Request) (recordedRequest, error) {
+	r := recordedRequest{
+		Method: req.Method,
+		Path:   apiPath(req.URL.Path),
+		Query:  req.URL.RawQuery,
+	}
+	if r.Path != "/graphql" || req.Body == nil {
+		return r, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return r, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return r, fmt.Errorf("invalid GraphQL request body: %w", err)
+	}
+	r.GraphQL = &recordedGraphQL{
+		Fields:    graphQLTopLevelFields(payload.Query),
+		Variables: payload.Variables,
+		query:     payload.Query,
+	}
+	return r, nil
+}
+
+// apiPath makes the path of a GitHub Enterprise Server request relative to
+// the API root, e.g. /api/v3/repos/o/r becomes /repos/o/r and /api/graphql
+// becomes /graphql. Paths on api.github.com already are.
+func apiPath(path string) string {
+	switch {
+	case path == "/api/graphql":
+		return "/graphql"
+	case strings.HasPrefix(path, "/api/v3/"):
+		return strings.TrimPrefix(path, "/api/v3")
+	}
+	return path
+}
+
+// graphQLTopLevelFields returns the top level selections of a GraphQL query,
+// with their alias if they have one, e.g. ["rateLimit", "repository"].
+func graphQLTopLevelFields(query string) []string {
+	var fields []string
+	braces, parens := 0, 0
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '{':
+			braces++
+		case c == '}':
+			braces--
+		case c == '(':
+			parens++
+		case c == ')':
+			parens--
+		case c == '@' && braces == 1 && parens == 0:
+			// Skip the name of a directive, its arguments are skipped as parens
+			for i+1 < len(query) && isGraphQLNameChar(query[i+1]) {
+				i++
+			}
+		case isGraphQLNameChar(c) && braces == 1 && parens == 0:
+			start := i
+			for i+1 < len(query) && (isGraphQLNameChar(query[i+1]) || query[i+1] == ':' || query[i+1] == ' ') {
+				i++
+			}
+			fields = append(fields, strings.ReplaceAll(query[start:i+1], " ", ""))
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func isGraphQLNameChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// matches returns whether a fixture request matches a request, and how
+// specific the match is.
+func (r recordedRequest) matches(req recordedRequest) (bool, int) {
+	if r.Method != req.Method || r.Path != req.Path {
+		return false, 0
+	}
+	want, err := url.ParseQuery(r.Query)
+	if err != nil {
+		return false, 0
+	}
+	got, err := url.ParseQuery(req.Query)
+	if err != nil || !reflect.DeepEqual(want, got) {
+		return false, 0
+	}
+	if r.GraphQL == nil || req.GraphQL == nil {
+		return r.GraphQL == nil && req.GraphQL == nil, 0
+	}
+	if !reflect.DeepEqual(r.GraphQL.Fields, req.GraphQL.Fields) {
+		return false, 0
+	}
+	for name, value := range r.GraphQL.Variables {
+		if !reflect.DeepEqual(normalizeJSON(value), normalizeJSON(req.GraphQL.Variables[name])) {
+			return false, 0
+		}
+	}
+	return true, len(r.GraphQL.Variables)
+}
+
+// normalizeJSON converts a value to its JSON decoded form, so that values of
+// different Go types compare equal when they encode the same.
+func normalizeJSON(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
+
+func (r recordedResponse) httpResponse(req *http.Request) *http.Response {
+	resp := &http.Response{
+		StatusCode: r.Status,
+		Status:     fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		Header:     http.Header{},
+		Request:    req,
+	}
+	for name, value := range r.Headers {
+		resp.Header.Set(name, value)
+	}
+	body := []byte(r.Text)
+	if len(r.Body) > 0 {
+		body = r.Body
+		if resp.Header.Get("Content-Type") == "" {
+			resp.Header.Set("Content-Type", "application/json; charset=utf-8")
+		}
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp
+}
+
+// replayTransport answers requests from the interactions of a fixture,
+// without any network access. Requests without a matching interaction get a
+// 404 response and are reported by unmatched.
+type replayTransport struct {
+	interactions []*interaction
+
+	mu        sync.Mutex
+	unmatched []string
+}
+
+func newReplayTransport(interactions []*interaction) *replayTransport {
+	return &replayTransport{interactions: interactions}
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r, err := newRecordedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *interaction
+	best := -1
+	for _, i := range t.interactions {
+		if ok, specificity := i.Request.matches(r); ok && specificity > best {
+			match, best = i, specificity
+		}
+	}
+	if match == nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		description, _ := json.Marshal(r)
+		if r.GraphQL != nil {
+			description = append(description, "\n"+r.GraphQL.query...)
+		}
+		t.unmatched = append(t.unmatched, string(description))
+		return recordedResponse{Status: http.StatusNotFound, Body: json.RawMessage(`{"message":"Not Found"}`)}.httpResponse(req), nil
+	}
+	return match.Response.httpResponse(req), nil
+}
+
+func (t *replayTransport) Unmatched() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.unmatched...)
+}
+
+// recordingTransport sends requests to the real GitHub API and records the
+// exchanges, so they can be saved as a fixture. Requests are sent to the
+// upstream API root if one is given, or else as they are.
+type recordingTransport struct {
+	base     http.RoundTripper
+	upstream *url.URL
+
+	mu           sync.Mutex
+	interactions []*interaction
+}
+
+func newRecordingTransport(base http.RoundTripper, upstream *url.URL) *recordingTransport {
+	return &recordingTransport{base: base, upstream: upstream}
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r, err := newRecordedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	out := req.Clone(req.Context())
+	if t.upstream != nil {
+		out.URL.Scheme = t.upstream.Scheme
+		out.URL.Host = t.upstream.Host
+		out.URL.Path = strings.TrimSuffix(t.upstream.Path, "/") + r.Path
+		out.URL.RawPath = ""
+		out.Host = t.upstream.Host
+	}
+	out.RequestURI = ""
+	// Let the transport negotiate compression, so the recorded body is plain
+	out.Header.Del("Accept-Encoding")
+
+	resp, err := t.base.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := recordedResponse{Status: resp.StatusCode, Headers: map[string]string{}}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			recorded.Headers[name] = value
+		}
+	}
+	if json.Valid(body) {
+		recorded.Body = body
+	} else {
+		recorded.Text = string(body)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.interactions = append(t.interactions, &interaction{Request: r, Response: recorded})
+	return resp, nil
+}
+
+func (t *recordingTransport) Interactions() []*interaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*interaction(nil), t.interactions...)
+}
+
+// testContext returns a context with the logger the plugin's functions log
+// to.
+func testContext() context.Context {
+	return context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+}
+
+// newReplayRESTClient returns a REST client built the way connect builds one,
+// with the base transport of the connection replaying the interactions.
+func newReplayRESTClient(t *testing.T, interactions []*interaction) (*github.Client, *replayTransport) {
+	t.Helper()
+	replay := newReplayTransport(interactions)
+	config := &clientConfig{Transport: replay}
+	client, err := newRESTClient(config, &http.Client{Transport: config.Transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, replay
+}
+
+func TestRecordedRequestMatches(t *testing.T) {
+	graphQL := func(query string, variables map[string]interface{}) recordedRequest {
+		return recordedRequest{Method: http.MethodPost, Path: "/graphql", GraphQL: &recordedGraphQL{Fields: graphQLTopLevelFields(query), Variables: variables}}
+	}
+
+	cases := []struct {
+		name            string
+		fixture         recordedRequest
+		request         recordedRequest
+		wantMatch       bool
+		wantSpecificity int
+	}{
+		{
+			name:      "same path",
+			fixture:   recordedRequest{Method: http.MethodGet, Path: "/repos/o/r"},
+			request:   recordedRequest{Method: http.MethodGet, Path: "/repos/o/r"},
+			wantMatch: true,
+		},
+		{
+			name:    "other method",
+			fixture: recordedRequest{Method: http.MethodGet, Path: "/repos/o/r"},
+			request: recordedRequest{Method: http.MethodDelete, Path: "/repos/o/r"},
+		},
+		{
+			name:      "query in another order",
+			fixture:   recordedRequest{Method: http.MethodGet, Path: "/user/repos", Query: "page=2&per_page=100"},
+			request:   recordedRequest{Method: http.MethodGet, Path: "/user/repos", Query: "per_page=100&page=2"},
+			wantMatch: true,
+		},
+		{
+			name:    "other query",
+			fixture: recordedRequest{Method: http.MethodGet, Path: "/user/repos", Query: "page=2"},
+			request: recordedRequest{Method: http.MethodGet, Path: "/user/repos", Query: "page=3"},
+		},
+		{
+			name:            "graphql variables subset",
+			fixture:         graphQL(`query{repository(owner:$owner){name},rateLimit{remaining}}`, map[string]interface{}{"owner": "o"}),
+			request:         graphQL(`query($owner:String!){rateLimit @include(if:$rl){remaining} repository(owner:$owner){name,id}}`, map[string]interface{}{"owner": "o", "rl": true}),
+			wantMatch:       true,
+			wantSpecificity: 1,
+		},
+		{
+			name:    "graphql other variable value",
+			fixture: graphQL(`query{repository{name}}`, map[string]interface{}{"owner": "o"}),
+			request: graphQL(`query{repository{name}}`, map[string]interface{}{"owner": "p"}),
+		},
+		{
+			name:      "graphql aliases",
+			fixture:   graphQL(`query{r0:repository{name},r1:repository{name}}`, nil),
+			request:   graphQL(`query{r1: repository(owner:"a"){name} r0: repository(owner:"b"){name}}`, nil),
+			wantMatch: true,
+		},
+		{
+			name:    "graphql other fields",
+			fixture: graphQL(`query{repository{name}}`, nil),
+			request: graphQL(`query{organization{name}}`, nil),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			match, specificity := tc.fixture.matches(tc.request)
+			if match != tc.wantMatch || specificity != tc.wantSpecificity {
+				t.Errorf("got match %t specificity %d, want match %t specificity %d", match, specificity, tc.wantMatch, tc.wantSpecificity)
+			}
+		})
+	}
+}
+
+// TestReplayTransport replays a fixture through the base transport of a
+// client, and reports the requests without a fixture.
+func TestReplayTransport(t *testing.T) {
+	client, replay := newReplayRESTClient(t, []*interaction{{
+		Request:  recordedRequest{Method: http.MethodGet, Path: "/repos/o/r"},
+		Response: recordedResponse{Status: http.StatusOK, Body: json.RawMessage(`{"full_name":"o/r"}`)},
+	}})
+
+	repo, _, err := client.Repositories.Get(context.Background(), "o", "r")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.GetFullName() != "o/r" {
+		t.Errorf("got repository %s, want o/r", repo.GetFullName())
+	}
+
+	if _, _, err := client.Repositories.Get(context.Background(), "o", "other"); err == nil {
+		t.Error("expected a request without a fixture to fail")
+	}
+	if unmatched := replay.Unmatched(); len(unmatched) != 1 || !strings.Contains(unmatched[0], "/repos/o/other") {
+		t.Errorf("expected the request without a fixture to be reported, got %v", unmatched)
+	}
+}
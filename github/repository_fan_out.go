@@ -0,0 +1,473 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+)
+
+// defaultFanOutConcurrency is how many repositories a query fans out to at
+// once when repository_fan_out_concurrency is not set.
+const defaultFanOutConcurrency = 10
+
+// withRepositoryFanOut lets a repository scoped table be queried without a
+// repository_full_name qualifier, for connections that configure
+// repositories or organizations. The list call is then run once for each
+// matching repository, as a matrix item, and the qualifier is set to the
+// repository for the rows of each, so that transform.FromQual populates the
+// column. Queries that set the qualifier run exactly as before.
+func withRepositoryFanOut(table *plugin.Table) {
+	if table.List == nil || table.List.ParentHydrate != nil {
+		return
+	}
+	var keyColumn *plugin.KeyColumn
+	for _, k := range table.List.KeyColumns {
+		if k.Name == "repository_full_name" && k.Require == plugin.Required {
+			keyColumn = k
+		}
+	}
+	if keyColumn == nil {
+		return
+	}
+
+	keyColumn.Require = plugin.Optional
+	// The rows of the repositories are listed at once, so they aren't in the
+	// order any column is listed in for a repository
+	for i, c := range table.Columns {
+		if c.Sort != plugin.SortNone {
+			unsorted := *c
+			unsorted.Sort = plugin.SortNone
+			table.Columns[i] = &unsorted
+		}
+	}
+	table.GetMatrixItemFunc = repositoryFanOutMatrix
+	table.List.Hydrate = repositoryFanOutList(table.List.Hydrate)
+}
+
+// hasRepositoryQual returns whether the query sets the repository_full_name
+// qualifier. The matrix is built before an `in` list is split into a list
+// call for each of its values, so the qualifier's value may be a list.
+func hasRepositoryQual(d *plugin.QueryData) bool {
+	q := d.EqualsQuals["repository_full_name"]
+	if q == nil {
+		return false
+	}
+	if list := q.GetListValue(); list != nil {
+		return len(list.Values) > 0
+	}
+	return q.GetStringValue() != ""
+}
+
+// repositoryFanOutMatrix returns a matrix item for each repository matching
+// the connection's repositories and organizations, or nil if the query sets
+// the repository_full_name qualifier. Errors can't be returned from a matrix
+// function, so they are reported by the list call instead.
+func repositoryFanOutMatrix(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	if hasRepositoryQual(d) {
+		return nil
+	}
+
+	repositories, err := getFanOutRepositories(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("repositoryFanOutMatrix", "error", err)
+		return nil
+	}
+	matrix := make([]map[string]interface{}, 0, len(repositories))
+	for _, r := range repositories {
+		matrix = append(matrix, map[string]interface{}{"repository_full_name": r})
+	}
+	return matrix
+}
+
+// repositoryFanOutList wraps the list call of a repository scoped table, to
+// bound how many repositories are listed at once.
+func repositoryFanOutList(list plugin.HydrateFunc) plugin.HydrateFunc {
+	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		if !hasRepositoryQual(d) {
+			// The matrix is only empty if no repository matches, or listing
+			// the repositories failed, in which case the error was cached
+			_, err := getFanOutRepositories(ctx, d)
+			return nil, err
+		}
+
+		if _, ok := plugin.GetMatrixItem(ctx)["repository_full_name"]; ok {
+			config, err := getClientConfig(ctx, d)
+			if err != nil {
+				return nil, err
+			}
+			select {
+			case config.FanOutSlots <- struct{}{}:
+				defer func() { <-config.FanOutSlots }()
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		return list(ctx, d, h)
+	}
+}
+
+// fanOut returns whether the repository scoped tables of the connection fan
+// out across the configured repositories when queried without a
+// repository_full_name.
+func (c githubConfig) fanOut() bool {
+	return len(c.Repositories) > 0 || len(c.Organizations) > 0
+}
+
+// fanOutErrorTTL is how long a failure to list the repositories to fan out
+// to is cached for. It spans the matrix function and the list call of a
+// query, which both need the repositories, without holding on to a transient
+// error.
+const fanOutErrorTTL = 30 * time.Second
+
+// fanOutRepositories is the cached result of listing the repositories to fan
+// out to.
+type fanOutRepositories struct {
+	repositories []string
+	err          error
+}
+
+// getFanOutRepositories returns the full names of the repositories matching
+// the connection's repositories and organizations, sorted.
+func getFanOutRepositories(ctx context.Context, d *plugin.QueryData) ([]string, error) {
+
+	// Load repositories from cache. The repositories and accounts visible
+	// differ between the credentials of the connection.
+	cacheKey, err := viewerCacheKey(ctx, d, "github_fan_out_repositories")
+	if err != nil {
+		return nil, err
+	}
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		cached := cachedData.(*fanOutRepositories)
+		return cached.repositories, cached.err
+	}
+
+	config, err := getClientConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	var clients fanOutClients
+	if config.DiscoverInstallations {
+		clients, err = getInstallationFanOut(ctx, d, config)
+	} else {
+		clients, err = getCredentialFanOut(ctx, d)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	repositories, err := listFanOutRepositories(ctx, clients, config.Repositories, config.Organizations)
+	if err != nil {
+		plugin.Logger(ctx).Error("getFanOutRepositories", "api_error", err)
+		d.ConnectionManager.Cache.SetWithTTL(cacheKey, &fanOutRepositories{err: err}, fanOutErrorTTL)
+		return nil, err
+	}
+	plugin.Logger(ctx).Debug("getFanOutRepositories", "repositories", len(repositories))
+
+	// Save to cache
+	d.ConnectionManager.Cache.Set(cacheKey, &fanOutRepositories{repositories: repositories})
+
+	return repositories, nil
+}
+
+// fanOutClients are the clients the repositories to fan out to are listed
+// with.
+type fanOutClients interface {
+	// accounts returns the logins of the accounts owner patterns are matched
+	// against
+	accounts(ctx context.Context) ([]string, error)
+	// forOwner returns the client that lists the repositories of an owner,
+	// and the kind of its credential
+	forOwner(ctx context.Context, owner string) (*github.Client, credentialKind, error)
+}
+
+// credentialFanOut lists the repositories of every owner with the credential
+// the query is pinned to.
+type credentialFanOut struct {
+	client *github.Client
+	kind   credentialKind
+}
+
+func getCredentialFanOut(ctx context.Context, d *plugin.QueryData) (*credentialFanOut, error) {
+	q, err := getQueryCredential(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	return &credentialFanOut{client: client, kind: q.current().kind}, nil
+}
+
+func (f *credentialFanOut) accounts(ctx context.Context) ([]string, error) {
+	return listAccounts(ctx, f.client, f.kind)
+}
+
+func (f *credentialFanOut) forOwner(_ context.Context, _ string) (*github.Client, credentialKind, error) {
+	return f.client, f.kind, nil
+}
+
+// installationFanOut lists the repositories of each owner with the
+// installation of the connection's GitHub App on its account, when the
+// installations are discovered, so that the private repositories of every
+// account are listed. Owners without an installation are listed with the
+// oldest installation, which only lists their public repositories.
+type installationFanOut struct {
+	appID         int64
+	installations []*github.Installation
+	// client returns the client that authenticates as an installation
+	client func(ctx context.Context, installation *github.Installation) (*github.Client, error)
+}
+
+// accounts returns the accounts the app is installed on.
+func (f *installationFanOut) accounts(_ context.Context) ([]string, error) {
+	var accounts []string
+	for _, i := range f.installations {
+		accounts = append(accounts, i.GetAccount().GetLogin())
+	}
+	return accounts, nil
+}
+
+func (f *installationFanOut) forOwner(ctx context.Context, owner string) (*github.Client, credentialKind, error) {
+	installation, err := selectInstallation(f.installations, []string{owner})
+	if err != nil {
+		return nil, "", fmt.Errorf("the GitHub App %d %w", f.appID, err)
+	}
+	client, err := f.client(ctx, installation)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, credentialKindAppInstallation, nil
+}
+
+// listFanOutRepositories returns the full names of the repositories matching
+// the repository patterns or in the organizations, sorted. Patterns prefixed
+// with "!" exclude the repositories they match.
+func listFanOutRepositories(ctx context.Context, clients fanOutClients, patterns []string, organizations []string) ([]string, error) {
+	var include, exclude []string
+	for _, p := range patterns {
+		if strings.HasPrefix(p, "!") {
+			exclude = append(exclude, strings.TrimPrefix(p, "!"))
+		} else {
+			include = append(include, p)
+		}
+	}
+
+	// Owners are only listed if a pattern needs their repositories
+	matched := map[string]bool{}
+	owners := map[string][]string{}
+	for _, p := range include {
+		owner, name := parseRepoFullName(p)
+		if !hasGlob(p) {
+			matched[p] = true
+			continue
+		}
+		owners[owner] = append(owners[owner], name)
+	}
+	for _, org := range organizations {
+		owners[org] = append(owners[org], "*")
+	}
+
+	// Owner patterns are matched against the accounts of the authenticated
+	// user, or of the installations' repositories, as there is no listing
+	// every account
+	var accounts []string
+	var err error
+	expanded := map[string][]string{}
+	for owner, names := range owners {
+		if !hasGlob(owner) {
+			expanded[owner] = append(expanded[owner], names...)
+			continue
+		}
+		if accounts == nil {
+			accounts, err = clients.accounts(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, account := range accounts {
+			if ok, _ := path.Match(owner, account); ok {
+				expanded[account] = append(expanded[account], names...)
+			}
+		}
+	}
+
+	for owner, names := range expanded {
+		client, kind, err := clients.forOwner(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+		repositories, err := listOwnerRepositories(ctx, client, kind, owner)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range repositories {
+			for _, name := range names {
+				if ok, _ := path.Match(name, r.GetName()); ok {
+					matched[r.GetFullName()] = true
+				}
+			}
+		}
+	}
+
+	var repositories []string
+	for r := range matched {
+		excluded := false
+		for _, p := range exclude {
+			if ok, _ := path.Match(p, r); ok {
+				excluded = true
+			}
+		}
+		if !excluded {
+			repositories = append(repositories, r)
+		}
+	}
+	sort.Strings(repositories)
+	return repositories, nil
+}
+
+// listAccounts returns the login of the authenticated user and of the
+// organizations they belong to, against which owner patterns are matched. An
+// installation has no authenticated user, so the owners of the repositories
+// it can access are returned instead.
+func listAccounts(ctx context.Context, client *github.Client, kind credentialKind) ([]string, error) {
+	if kind.isInstallation() {
+		repositories, err := listInstallationRepositories(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		var accounts []string
+		seen := map[string]bool{}
+		for _, r := range repositories {
+			if login := r.GetOwner().GetLogin(); !seen[login] {
+				seen[login] = true
+				accounts = append(accounts, login)
+			}
+		}
+		return accounts, nil
+	}
+
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	accounts := []string{user.GetLogin()}
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		orgs, resp, err := client.Organizations.List(ctx, "", opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range orgs {
+			accounts = append(accounts, o.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return accounts, nil
+}
+
+// listOwnerRepositories returns the repositories of an organization, or of a
+// user if there is no organization with the login.
+func listOwnerRepositories(ctx context.Context, client *github.Client, kind credentialKind, owner string) ([]*github.Repository, error) {
+	var repositories []*github.Repository
+
+	opts := &github.RepositoryListByOrgOptions{Type: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		repos, resp, err := client.Repositories.ListByOrg(ctx, owner, opts)
+		if err != nil {
+			if resp != nil && resp.StatusCode == 404 {
+				return listUserRepositories(ctx, client, kind, owner)
+			}
+			return nil, err
+		}
+		repositories = append(repositories, repos...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return repositories, nil
+}
+
+// listUserRepositories returns the repositories owned by a user. Private
+// repositories are only listed for the authenticated user, or for an
+// installation on the user's account.
+func listUserRepositories(ctx context.Context, client *github.Client, kind credentialKind, user string) ([]*github.Repository, error) {
+	if kind.isInstallation() {
+		repositories, err := listInstallationRepositories(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		var owned []*github.Repository
+		for _, r := range repositories {
+			if strings.EqualFold(r.GetOwner().GetLogin(), user) {
+				owned = append(owned, r)
+			}
+		}
+		// Another account's repositories are listed like any other
+		// account's, which only lists the public ones
+		if len(owned) > 0 {
+			return owned, nil
+		}
+	} else {
+		viewer, _, err := client.Users.Get(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(viewer.GetLogin(), user) {
+			return listRepositories(ctx, client, "", &github.RepositoryListOptions{Affiliation: "owner", ListOptions: github.ListOptions{PerPage: 100}})
+		}
+	}
+	return listRepositories(ctx, client, user, &github.RepositoryListOptions{Type: "owner", ListOptions: github.ListOptions{PerPage: 100}})
+}
+
+func listRepositories(ctx context.Context, client *github.Client, user string, opts *github.RepositoryListOptions) ([]*github.Repository, error) {
+	var repositories []*github.Repository
+	for {
+		repos, resp, err := client.Repositories.List(ctx, user, opts)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, repos...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return repositories, nil
+}
+
+// listInstallationRepositories returns the repositories the installation the
+// client authenticates as can access.
+func listInstallationRepositories(ctx context.Context, client *github.Client) ([]*github.Repository, error) {
+	var repositories []*github.Repository
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		repos, resp, err := client.Apps.ListRepos(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, repos.Repositories...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return repositories, nil
+}
+
+// hasGlob returns whether the pattern has any of the path.Match
+// metacharacters.
+func hasGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
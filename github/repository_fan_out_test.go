@@ -0,0 +1,213 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+)
+
+// repositoriesResponse returns a response listing the repositories, given by
+// their full names.
+func repositoriesResponse(fullNames ...string) recordedResponse {
+	var repos []string
+	for _, fullName := range fullNames {
+		owner, name := parseRepoFullName(fullName)
+		repos = append(repos, fmt.Sprintf(`{"name":%q,"full_name":%q,"owner":{"login":%q}}`, name, fullName, owner))
+	}
+	return recordedResponse{Status: http.StatusOK, Body: json.RawMessage("[" + strings.Join(repos, ",") + "]")}
+}
+
+func TestListFanOutRepositories(t *testing.T) {
+	get := func(path, query string, resp recordedResponse) *interaction {
+		return &interaction{Request: recordedRequest{Method: http.MethodGet, Path: path, Query: query}, Response: resp}
+	}
+	installationRepositories := repositoriesResponse("acme-eu/api", "octocat/dotfiles", "octocat/blog")
+	installationRepositories.Body = json.RawMessage(`{"total_count":3,"repositories":` + string(installationRepositories.Body) + `}`)
+	notFound := recordedResponse{Status: http.StatusNotFound, Body: json.RawMessage(`{"message":"Not Found"}`)}
+
+	cases := []struct {
+		name          string
+		kind          credentialKind
+		patterns      []string
+		organizations []string
+		interactions  []*interaction
+		want          []string
+	}{
+		{
+			name:     "full names are not listed",
+			kind:     credentialKindClassicPersonalAccessToken,
+			patterns: []string{"acme/api", "acme/web"},
+			want:     []string{"acme/api", "acme/web"},
+		},
+		{
+			name:     "name patterns and exclusions",
+			kind:     credentialKindClassicPersonalAccessToken,
+			patterns: []string{"acme/*", "!acme/archive-*", "!other/*"},
+			interactions: []*interaction{
+				get("/orgs/acme/repos", "per_page=100&type=all", repositoriesResponse("acme/api", "acme/archive-2019", "acme/web")),
+			},
+			want: []string{"acme/api", "acme/web"},
+		},
+		{
+			name:          "organizations",
+			kind:          credentialKindClassicPersonalAccessToken,
+			patterns:      []string{"acme/w?b", "!acme/legacy"},
+			organizations: []string{"acme"},
+			interactions: []*interaction{
+				get("/orgs/acme/repos", "per_page=100&type=all", repositoriesResponse("acme/api", "acme/legacy", "acme/web")),
+			},
+			want: []string{"acme/api", "acme/web"},
+		},
+		{
+			name:     "owner patterns for a user",
+			kind:     credentialKindClassicPersonalAccessToken,
+			patterns: []string{"acme-*/api"},
+			interactions: []*interaction{
+				get("/user", "", recordedResponse{Status: http.StatusOK, Body: json.RawMessage(`{"login":"octocat"}`)}),
+				get("/user/orgs", "per_page=100", recordedResponse{Status: http.StatusOK, Body: json.RawMessage(`[{"login":"acme-eu"},{"login":"acme-us"},{"login":"other"}]`)}),
+				get("/orgs/acme-eu/repos", "per_page=100&type=all", repositoriesResponse("acme-eu/api", "acme-eu/web")),
+				get("/orgs/acme-us/repos", "per_page=100&type=all", repositoriesResponse("acme-us/api")),
+			},
+			want: []string{"acme-eu/api", "acme-us/api"},
+		},
+		{
+			name:     "repositories of the authenticated user",
+			kind:     credentialKindFineGrainedPersonalAccessToken,
+			patterns: []string{"OctoCat/*"},
+			interactions: []*interaction{
+				get("/orgs/OctoCat/repos", "per_page=100&type=all", notFound),
+				get("/user", "", recordedResponse{Status: http.StatusOK, Body: json.RawMessage(`{"login":"octocat"}`)}),
+				get("/user/repos", "affiliation=owner&per_page=100", repositoriesResponse("octocat/dotfiles", "octocat/private")),
+			},
+			want: []string{"octocat/dotfiles", "octocat/private"},
+		},
+		{
+			name:     "repositories of another user",
+			kind:     credentialKindClassicPersonalAccessToken,
+			patterns: []string{"torvalds/*"},
+			interactions: []*interaction{
+				get("/orgs/torvalds/repos", "per_page=100&type=all", notFound),
+				get("/user", "", recordedResponse{Status: http.StatusOK, Body: json.RawMessage(`{"login":"octocat"}`)}),
+				get("/users/torvalds/repos", "per_page=100&type=owner", repositoriesResponse("torvalds/linux")),
+			},
+			want: []string{"torvalds/linux"},
+		},
+		{
+			name:     "owner patterns for an installation",
+			kind:     credentialKindAppInstallation,
+			patterns: []string{"acme-*/*", "!acme-eu/web"},
+			interactions: []*interaction{
+				get("/installation/repositories", "per_page=100", installationRepositories),
+				get("/orgs/acme-eu/repos", "per_page=100&type=all", repositoriesResponse("acme-eu/api", "acme-eu/web")),
+			},
+			want: []string{"acme-eu/api"},
+		},
+		{
+			name:     "user repositories for an installation",
+			kind:     credentialKindInstallationAccessToken,
+			patterns: []string{"octocat/*", "torvalds/*"},
+			interactions: []*interaction{
+				get("/orgs/octocat/repos", "per_page=100&type=all", notFound),
+				get("/orgs/torvalds/repos", "per_page=100&type=all", notFound),
+				get("/installation/repositories", "per_page=100", installationRepositories),
+				get("/users/torvalds/repos", "per_page=100&type=owner", repositoriesResponse("torvalds/linux")),
+			},
+			want: []string{"octocat/blog", "octocat/dotfiles", "torvalds/linux"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, replay := newReplayRESTClient(t, tc.interactions)
+			got, err := listFanOutRepositories(testContext(), &credentialFanOut{client: client, kind: tc.kind}, tc.patterns, tc.organizations)
+			if err != nil {
+				t.Fatalf("%s\nunmatched requests:\n%s", err, strings.Join(replay.Unmatched(), "\n"))
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			if unmatched := replay.Unmatched(); len(unmatched) > 0 {
+				t.Errorf("requests without a fixture:\n%s", strings.Join(unmatched, "\n"))
+			}
+		})
+	}
+}
+
+// TestListFanOutRepositoriesAcrossInstallations makes sure that each owner is
+// listed with the installation on its own account, which is the only one that
+// can list its private repositories.
+func TestListFanOutRepositoriesAcrossInstallations(t *testing.T) {
+	get := func(path, query string, resp recordedResponse) *interaction {
+		return &interaction{Request: recordedRequest{Method: http.MethodGet, Path: path, Query: query}, Response: resp}
+	}
+	installation := func(id int64, login string) *github.Installation {
+		return &github.Installation{ID: github.Int64(id), Account: &github.User{Login: github.String(login)}}
+	}
+
+	acme, acmeReplay := newReplayRESTClient(t, []*interaction{
+		get("/orgs/acme/repos", "per_page=100&type=all", repositoriesResponse("acme/api", "acme/private")),
+	})
+	globex, globexReplay := newReplayRESTClient(t, []*interaction{
+		get("/orgs/globex-eu/repos", "per_page=100&type=all", repositoriesResponse("globex-eu/billing", "globex-eu/internal")),
+	})
+	clients := map[int64]*github.Client{1: acme, 2: globex}
+	fanOut := &installationFanOut{
+		appID:         7,
+		installations: []*github.Installation{installation(1, "acme"), installation(2, "globex-eu")},
+		client: func(_ context.Context, i *github.Installation) (*github.Client, error) {
+			return clients[i.GetID()], nil
+		},
+	}
+
+	got, err := listFanOutRepositories(testContext(), fanOut, []string{"globex-*/*", "!globex-eu/internal"}, []string{"acme"})
+	if err != nil {
+		t.Fatalf("%s\nunmatched requests:\n%s\n%s", err, strings.Join(acmeReplay.Unmatched(), "\n"), strings.Join(globexReplay.Unmatched(), "\n"))
+	}
+	want := []string{"acme/api", "acme/private", "globex-eu/billing"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for name, replay := range map[string]*replayTransport{"acme": acmeReplay, "globex-eu": globexReplay} {
+		if unmatched := replay.Unmatched(); len(unmatched) > 0 {
+			t.Errorf("requests the installation on %s has no fixture for:\n%s", name, strings.Join(unmatched, "\n"))
+		}
+	}
+}
+
+// TestRepositoryFanOutKeyColumn checks that repository_full_name is only
+// optional for the connections that fan out, which don't list rows in the
+// order of a column.
+func TestRepositoryFanOutKeyColumn(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  githubConfig
+		require string
+	}{
+		{name: "no fan out", config: githubConfig{}, require: plugin.Required},
+		{name: "repositories", config: githubConfig{Repositories: []string{"acme/*"}}, require: plugin.Optional},
+		{name: "organizations", config: githubConfig{Organizations: []string{"acme"}}, require: plugin.Optional},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			table := testTables(t, test.config)["github_pull_request"]
+			keyColumn := table.List.KeyColumns.Find("repository_full_name")
+			if keyColumn.Require != test.require {
+				t.Errorf("repository_full_name is %s, want %s", keyColumn.Require, test.require)
+			}
+			if fanOut := table.GetMatrixItemFunc != nil; fanOut != (test.require == plugin.Optional) {
+				t.Errorf("fan out matrix set: %t", fanOut)
+			}
+			for _, c := range table.Columns {
+				if c.Name == "updated_at" && (c.Sort == plugin.SortAll) != (test.require == plugin.Required) {
+					t.Errorf("updated_at sort is %s", c.Sort)
+				}
+			}
+		})
+	}
+}
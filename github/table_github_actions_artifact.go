@@ -42,7 +42,10 @@ func tableGitHubActionsArtifact() *plugin.Table {
 }
 
 func tableGitHubArtifactList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	fullName := d.EqualsQuals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(fullName)
@@ -94,7 +97,10 @@ func tableGitHubArtifactGet(ctx context.Context, d *plugin.QueryData, h *plugin.
 	owner, repo := parseRepoFullName(fullName)
 	plugin.Logger(ctx).Trace("tableGitHubArtifactGet", "owner", owner, "repo", repo, "id", id)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	artifact, _, err := client.Actions.GetArtifact(ctx, owner, repo, id)
 	if err != nil {
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubActionsEnterpriseRunner() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_actions_enterprise_runner",
+		Description: "Self-hosted runners registered in an enterprise, which run the jobs of GitHub Actions workflows in the repositories of its organizations.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "enterprise", Require: plugin.Required},
+				{Name: "runner_group_id", Require: plugin.Optional},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsEnterpriseRunnerList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"enterprise", "id"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsEnterpriseRunnerGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "enterprise", Type: proto.ColumnType_STRING, Transform: transform.FromQual("enterprise"), Description: "The slug of the enterprise."},
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromGo(), Description: "The unique identifier of the runner."},
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The name of the runner."},
+			{Name: "status", Type: proto.ColumnType_STRING, Description: "The status of the runner, either online or offline."},
+			{Name: "busy", Type: proto.ColumnType_BOOL, Description: "If true, the runner is running a job."},
+			{Name: "label_names", Type: proto.ColumnType_JSON, Transform: transform.FromField("Labels").Transform(runnerLabelNames), Description: "The names of the labels of the runner, which jobs select runners by."},
+
+			// Other columns
+			{Name: "runner_group_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("runner_group_id"), Description: "The ID of the enterprise runner group, if the runners are listed by runner group."},
+			{Name: "os", Type: proto.ColumnType_STRING, Transform: transform.FromField("OS"), Description: "The operating system of the runner."},
+			{Name: "labels", Type: proto.ColumnType_JSON, Description: "The labels of the runner, with their IDs and types, either read-only or custom."},
+		}),
+	}
+}
+
+func tableGitHubActionsEnterpriseRunnerList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	enterprise := d.EqualsQualString("enterprise")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		var runners *github.Runners
+		var resp *github.Response
+		if d.EqualsQuals["runner_group_id"] != nil {
+			runners, resp, err = listEnterpriseRunnerGroupRunners(ctx, client, enterprise, d.EqualsQuals["runner_group_id"].GetInt64Value(), opts)
+		} else {
+			runners, resp, err = client.Enterprise.ListRunners(ctx, enterprise, opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range runners.Runners {
+			if i != nil {
+				d.StreamListItem(ctx, i)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubActionsEnterpriseRunnerGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	enterprise := d.EqualsQualString("enterprise")
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// go-github has no method to get an enterprise runner
+	req, err := client.NewRequest("GET", fmt.Sprintf("enterprises/%s/actions/runners/%d", enterprise, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	runner := &github.Runner{}
+	if _, err := client.Do(ctx, req, runner); err != nil {
+		return nil, err
+	}
+
+	return runner, nil
+}
+
+// listEnterpriseRunnerGroupRunners lists a page of the runners of an
+// enterprise runner group, which go-github has no method for.
+func listEnterpriseRunnerGroupRunners(ctx context.Context, client *github.Client, enterprise string, groupID int64, opts *github.ListOptions) (*github.Runners, *github.Response, error) {
+	params := url.Values{}
+	params.Set("per_page", strconv.Itoa(opts.PerPage))
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("enterprises/%s/actions/runner-groups/%d/runners?%s", enterprise, groupID, params.Encode()), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	runners := &github.Runners{}
+	resp, err := client.Do(ctx, req, runners)
+	if err != nil {
+		return nil, resp, err
+	}
+	return runners, resp, nil
+}
@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+// enterpriseRunnerGroup is a runner group of an enterprise, which selects the
+// organizations that can use it rather than repositories. go-github has no
+// methods for enterprise runner groups.
+type enterpriseRunnerGroup struct {
+	github.RunnerGroup
+	SelectedOrganizationsURL *string `json:"selected_organizations_url,omitempty"`
+}
+
+type enterpriseRunnerGroups struct {
+	TotalCount   int                      `json:"total_count"`
+	RunnerGroups []*enterpriseRunnerGroup `json:"runner_groups"`
+}
+
+func tableGitHubActionsEnterpriseRunnerGroup() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_actions_enterprise_runner_group",
+		Description: "Runner groups control which organizations of an enterprise, and which workflows, can run jobs on its self-hosted runners.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.SingleColumn("enterprise"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsEnterpriseRunnerGroupList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"enterprise", "id"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsEnterpriseRunnerGroupGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "enterprise", Type: proto.ColumnType_STRING, Transform: transform.FromQual("enterprise"), Description: "The slug of the enterprise."},
+			{Name: "id", Type: proto.ColumnType_INT, Description: "Unique ID of the runner group."},
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The name of the runner group."},
+			{Name: "visibility", Type: proto.ColumnType_STRING, Description: "The organizations that can use the runner group, either all or selected."},
+			{Name: "allows_public_repositories", Type: proto.ColumnType_BOOL, Description: "If true, public repositories can use the runner group."},
+			{Name: "restricted_to_workflows", Type: proto.ColumnType_BOOL, Description: "If true, only the selected workflows can use the runner group."},
+			{Name: "selected_workflows", Type: proto.ColumnType_JSON, Description: "The workflows that can use the runner group, if it is restricted to workflows, e.g. octo-org/octo-repo/.github/workflows/deploy.yaml@main."},
+
+			// Other columns
+			{Name: "default", Type: proto.ColumnType_BOOL, Description: "If true, the runner group is the default group, which new runners are added to."},
+			{Name: "selected_organizations_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SelectedOrganizationsURL"), Description: "The REST API URL of the organizations that can use the runner group, for selected visibility."},
+			{Name: "runners_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("RunnersURL"), Description: "The REST API URL of the runners of the runner group."},
+		}),
+	}
+}
+
+func tableGitHubActionsEnterpriseRunnerGroupList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	enterprise := d.EqualsQualString("enterprise")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("per_page", strconv.Itoa(adjustPageSize(100, d.QueryContext.Limit)))
+
+	for {
+		req, err := client.NewRequest("GET", fmt.Sprintf("enterprises/%s/actions/runner-groups?%s", enterprise, params.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+		groups := &enterpriseRunnerGroups{}
+		resp, err := client.Do(ctx, req, groups)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range groups.RunnerGroups {
+			if i != nil {
+				d.StreamListItem(ctx, i)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		params.Set("page", strconv.Itoa(resp.NextPage))
+	}
+
+	return nil, nil
+}
+
+func tableGitHubActionsEnterpriseRunnerGroupGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	enterprise := d.EqualsQualString("enterprise")
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("enterprises/%s/actions/runner-groups/%d", enterprise, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	group := &enterpriseRunnerGroup{}
+	if _, err := client.Do(ctx, req, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubActionsEnvironmentSecret() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_actions_environment_secret",
+		Description: "Secrets are encrypted environment variables that you create in a repository environment for use in GitHub Actions workflows.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "environment_name"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubEnvSecretList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "environment_name", "name"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubEnvSecretGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository that contains the environment."},
+			{Name: "environment_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("environment_name"), Description: "The name of the environment."},
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The name of the secret."},
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the secret was created."},
+			{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the secret was last updated."},
+		}),
+	}
+}
+
+func tableGitHubEnvSecretList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+	environmentName := d.EqualsQualString("environment_name")
+
+	// Get repository to obtain its ID
+	repository, _, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	if repository == nil || repository.ID == nil {
+		return nil, nil
+	}
+
+	opts := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		secrets, resp, err := client.Actions.ListEnvSecrets(ctx, int(*repository.ID), environmentName, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range secrets.Secrets {
+			if i != nil {
+				d.StreamListItem(ctx, i)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubEnvSecretGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("name")
+	environmentName := d.EqualsQualString("environment_name")
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get repository to obtain its ID
+	repository, _, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	if repository == nil || repository.ID == nil {
+		return nil, nil
+	}
+
+	secret, _, err := client.Actions.GetEnvSecret(ctx, int(*repository.ID), environmentName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
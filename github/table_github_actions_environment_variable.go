@@ -36,7 +36,10 @@ func tableGitHubActionsEnvironmentVariable() *plugin.Table {
 }
 
 func tableGitHubEnvVariableList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	repoFullName := d.EqualsQuals["repository_full_name"].GetStringValue()
 	environmentName := d.EqualsQuals["environment_name"].GetStringValue()
@@ -101,7 +104,10 @@ func tableGitHubEnvVariableGet(ctx context.Context, d *plugin.QueryData, h *plug
 	owner, repo := parseRepoFullName(repoFullName)
 	plugin.Logger(ctx).Trace("tableGitHubEnvVariableGet", "owner", owner, "repo", repo, "environment", environmentName, "name", name)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get repository to obtain its ID
 	repository, _, err := client.Repositories.Get(ctx, owner, repo)
@@ -0,0 +1,160 @@
+package github
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+// The GitHub Actions permission columns an organization and a repository
+// share, other than whether GitHub Actions is enabled.
+func gitHubActionsPermissionsColumns(selectedActions, workflowPermissions, forkPRApproval plugin.HydrateFunc) []*plugin.Column {
+	return []*plugin.Column{
+		// Top columns
+		{Name: "allowed_actions", Type: proto.ColumnType_STRING, Description: "The actions and reusable workflows that are allowed to run, either all, local_only or selected."},
+		{Name: "default_workflow_permissions", Type: proto.ColumnType_STRING, Hydrate: workflowPermissions, Description: "The default permissions of the GITHUB_TOKEN of workflows, either read or write."},
+		{Name: "can_approve_pull_request_reviews", Type: proto.ColumnType_BOOL, Hydrate: workflowPermissions, Description: "If true, workflows can approve pull requests, and create them."},
+
+		// Other columns
+		{Name: "github_owned_allowed", Type: proto.ColumnType_BOOL, Hydrate: selectedActions, Transform: transform.FromField("GithubOwnedAllowed"), Description: "If true, actions created by GitHub are allowed, when allowed_actions is selected."},
+		{Name: "verified_allowed", Type: proto.ColumnType_BOOL, Hydrate: selectedActions, Description: "If true, actions by verified creators in the GitHub Marketplace are allowed, when allowed_actions is selected."},
+		{Name: "patterns_allowed", Type: proto.ColumnType_JSON, Hydrate: selectedActions, Description: "The patterns of the other actions and reusable workflows that are allowed, e.g. monalisa/octocat@*, when allowed_actions is selected."},
+		{Name: "fork_pr_approval_policy", Type: proto.ColumnType_STRING, Hydrate: forkPRApproval, Transform: transform.FromField("ApprovalPolicy"), Description: "Which contributors need approval to run workflows on pull requests from forks, e.g. first_time_contributors or all_external_contributors."},
+		{Name: "selected_actions_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SelectedActionsURL"), Description: "The REST API URL of the actions that are allowed, when allowed_actions is selected."},
+	}
+}
+
+func tableGitHubActionsOrganizationPermissions() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_actions_organization_permissions",
+		Description: "The GitHub Actions permissions of an organization, including the actions that are allowed and the default permissions of workflows.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.SingleColumn("organization"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsOrganizationPermissionsList,
+		},
+		Columns: commonColumns(append([]*plugin.Column{
+			{Name: "organization", Type: proto.ColumnType_STRING, Transform: transform.FromQual("organization"), Description: "The login name of the organization."},
+			{Name: "enabled_repositories", Type: proto.ColumnType_STRING, Description: "The repositories GitHub Actions is enabled for, either all, none or selected."},
+		}, gitHubActionsPermissionsColumns(
+			tableGitHubActionsOrganizationPermissionsSelectedActions,
+			tableGitHubActionsOrganizationPermissionsWorkflow,
+			tableGitHubActionsOrganizationPermissionsForkPRApproval,
+		)...)),
+	}
+}
+
+// actionsWorkflowPermissions is the default workflow permissions of an
+// organization or repository, which the client library doesn't support.
+type actionsWorkflowPermissions struct {
+	DefaultWorkflowPermissions   *string `json:"default_workflow_permissions"`
+	CanApprovePullRequestReviews *bool   `json:"can_approve_pull_request_reviews"`
+}
+
+// actionsForkPRApproval is the policy for approving workflow runs on pull
+// requests from forks, which the client library doesn't support.
+type actionsForkPRApproval struct {
+	ApprovalPolicy *string `json:"approval_policy"`
+}
+
+func tableGitHubActionsOrganizationPermissionsList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, _, err := client.Organizations.GetActionsPermissions(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	d.StreamListItem(ctx, permissions)
+
+	return nil, nil
+}
+
+func tableGitHubActionsOrganizationPermissionsSelectedActions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// The allowed actions are only listed if they are selected
+	if h.Item.(*github.ActionsPermissions).GetAllowedActions() != "selected" {
+		return nil, nil
+	}
+
+	org := d.EqualsQualString("organization")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, _, err := client.Organizations.GetActionsAllowed(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	return allowed, nil
+}
+
+func tableGitHubActionsOrganizationPermissionsWorkflow(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return getActionsWorkflowPermissions(ctx, client, "orgs/"+org)
+}
+
+func tableGitHubActionsOrganizationPermissionsForkPRApproval(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return getActionsForkPRApproval(ctx, client, "orgs/"+org)
+}
+
+// getActionsWorkflowPermissions gets the default workflow permissions of the
+// organization or repository at the given path, e.g. orgs/turbot.
+func getActionsWorkflowPermissions(ctx context.Context, client *github.Client, path string) (*actionsWorkflowPermissions, error) {
+	req, err := client.NewRequest("GET", path+"/actions/permissions/workflow", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions actionsWorkflowPermissions
+	if _, err := client.Do(ctx, req, &permissions); err != nil {
+		return nil, err
+	}
+
+	return &permissions, nil
+}
+
+// getActionsForkPRApproval gets the fork pull request approval policy of the
+// organization or repository at the given path. GitHub Enterprise Server
+// versions without the endpoint return a 404, which leaves the policy null.
+func getActionsForkPRApproval(ctx context.Context, client *github.Client, path string) (*actionsForkPRApproval, error) {
+	req, err := client.NewRequest("GET", path+"/actions/permissions/fork-pr-contributor-approval", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var approval actionsForkPRApproval
+	if _, err := client.Do(ctx, req, &approval); err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &approval, nil
+}
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubActionsOrganizationRunner() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_actions_organization_runner",
+		Description: "Self-hosted runners registered in an organization, which run the jobs of GitHub Actions workflows in its repositories.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "organization", Require: plugin.Required},
+				{Name: "runner_group_id", Require: plugin.Optional},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsOrganizationRunnerList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"organization", "id"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsOrganizationRunnerGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "organization", Type: proto.ColumnType_STRING, Transform: transform.FromQual("organization"), Description: "The login name of the organization."},
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromGo(), Description: "The unique identifier of the runner."},
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The name of the runner."},
+			{Name: "status", Type: proto.ColumnType_STRING, Description: "The status of the runner, either online or offline."},
+			{Name: "busy", Type: proto.ColumnType_BOOL, Description: "If true, the runner is running a job."},
+			{Name: "label_names", Type: proto.ColumnType_JSON, Transform: transform.FromField("Labels").Transform(runnerLabelNames), Description: "The names of the labels of the runner, which jobs select runners by."},
+
+			// Other columns
+			{Name: "runner_group_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("runner_group_id"), Description: "The ID of the runner group, if the runners are listed by runner group."},
+			{Name: "os", Type: proto.ColumnType_STRING, Transform: transform.FromField("OS"), Description: "The operating system of the runner."},
+			{Name: "labels", Type: proto.ColumnType_JSON, Description: "The labels of the runner, with their IDs and types, either read-only or custom."},
+		}),
+	}
+}
+
+func tableGitHubActionsOrganizationRunnerList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		var runners *github.Runners
+		var resp *github.Response
+		if d.EqualsQuals["runner_group_id"] != nil {
+			runners, resp, err = client.Actions.ListRunnerGroupRunners(ctx, org, d.EqualsQuals["runner_group_id"].GetInt64Value(), opts)
+		} else {
+			runners, resp, err = client.Actions.ListOrganizationRunners(ctx, org, opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range runners.Runners {
+			if i != nil {
+				d.StreamListItem(ctx, i)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubActionsOrganizationRunnerGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	runner, _, err := client.Actions.GetOrganizationRunner(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return runner, nil
+}
+
+// runnerLabelNames returns the names of the labels of a runner.
+func runnerLabelNames(_ context.Context, input *transform.TransformData) (interface{}, error) {
+	labels, ok := input.Value.([]*github.RunnerLabels)
+	if !ok {
+		return nil, nil
+	}
+
+	names := []string{}
+	for _, l := range labels {
+		names = append(names, l.GetName())
+	}
+	return names, nil
+}
@@ -0,0 +1,131 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+// The Actions, Dependabot and Codespaces services have the same methods for
+// organization secrets, so the tables of their secrets share the functions
+// below, and are given the methods of their service to call.
+type orgSecretListFunc func(ctx context.Context, org string, opts *github.ListOptions) (*github.Secrets, *github.Response, error)
+type orgSecretGetFunc func(ctx context.Context, org, name string) (*github.Secret, *github.Response, error)
+type orgSecretReposListFunc func(ctx context.Context, org, name string, opts *github.ListOptions) (*github.SelectedReposList, *github.Response, error)
+
+func gitHubOrganizationSecretColumns() []*plugin.Column {
+	return []*plugin.Column{
+		// Top columns
+		{Name: "organization", Type: proto.ColumnType_STRING, Transform: transform.FromQual("organization"), Description: "The login name of the organization."},
+		{Name: "name", Type: proto.ColumnType_STRING, Description: "The name of the secret."},
+		{Name: "visibility", Type: proto.ColumnType_STRING, Transform: transform.FromField("Visibility").NullIfZero(), Description: "The repositories that can access the secret, either all, private or selected."},
+		{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the secret was last updated."},
+
+		// Other columns
+		{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the secret was created."},
+		{Name: "selected_repositories_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SelectedRepositoriesURL").NullIfZero(), Description: "The REST API URL of the repositories that can access the secret, for selected visibility."},
+	}
+}
+
+func tableGitHubActionsOrganizationSecret() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_actions_organization_secret",
+		Description: "Secrets are encrypted environment variables created in an organization for use in GitHub Actions workflows.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.SingleColumn("organization"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsOrganizationSecretList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"organization", "name"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsOrganizationSecretGet,
+		},
+		Columns: commonColumns(gitHubOrganizationSecretColumns()),
+	}
+}
+
+func tableGitHubActionsOrganizationSecretList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, listOrganizationSecrets(ctx, d, client.Actions.ListOrgSecrets, &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)})
+}
+
+func tableGitHubActionsOrganizationSecretGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return getOrganizationSecret(ctx, d, client.Actions.GetOrgSecret)
+}
+
+// organizationSecretFuncs returns the methods for listing the organization
+// secrets of an app, which is actions, dependabot or codespaces, and the
+// repositories selected for them.
+func organizationSecretFuncs(client *github.Client, app string) (orgSecretListFunc, orgSecretReposListFunc, error) {
+	switch app {
+	case "actions":
+		return client.Actions.ListOrgSecrets, client.Actions.ListSelectedReposForOrgSecret, nil
+	case "dependabot":
+		return client.Dependabot.ListOrgSecrets, client.Dependabot.ListSelectedReposForOrgSecret, nil
+	case "codespaces":
+		return client.Codespaces.ListOrgSecrets, client.Codespaces.ListSelectedReposForOrgSecret, nil
+	}
+	return nil, nil, fmt.Errorf("invalid app %q, must be one of actions, dependabot or codespaces", app)
+}
+
+func listOrganizationSecrets(ctx context.Context, d *plugin.QueryData, list orgSecretListFunc, opts *github.ListOptions) error {
+	org := d.EqualsQualString("organization")
+
+	for {
+		secrets, resp, err := list(ctx, org, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range secrets.Secrets {
+			if i != nil {
+				d.StreamListItem(ctx, i)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil
+}
+
+func getOrganizationSecret(ctx context.Context, d *plugin.QueryData, get orgSecretGetFunc) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+	name := d.EqualsQualString("name")
+
+	// Empty check for the parameters
+	if org == "" || name == "" {
+		return nil, nil
+	}
+
+	secret, _, err := get(ctx, org, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
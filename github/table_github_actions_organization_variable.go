@@ -37,7 +37,10 @@ func tableGitHubActionsOrganizationVariable() *plugin.Table {
 }
 
 func tableGitHubOrgVariableList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	org := d.EqualsQuals["organization"].GetStringValue()
 	opts := &github.ListOptions{PerPage: 100}
@@ -87,7 +90,10 @@ func tableGitHubOrgVariableGet(ctx context.Context, d *plugin.QueryData, h *plug
 
 	plugin.Logger(ctx).Trace("tableGitHubOrgVariableGet", "org", org, "name", name)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	type GetResponse struct {
 		variable *github.ActionsVariable
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubActionsRepositoryPermissions() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_actions_repository_permissions",
+		Description: "The GitHub Actions permissions of a repository, including the actions that are allowed and the default permissions of workflows.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.SingleColumn("repository_full_name"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsRepositoryPermissionsList,
+		},
+		Columns: commonColumns(append([]*plugin.Column{
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository."},
+			{Name: "enabled", Type: proto.ColumnType_BOOL, Description: "If true, GitHub Actions is enabled for the repository."},
+		}, gitHubActionsPermissionsColumns(
+			tableGitHubActionsRepositoryPermissionsSelectedActions,
+			tableGitHubActionsRepositoryPermissionsWorkflow,
+			tableGitHubActionsRepositoryPermissionsForkPRApproval,
+		)...)),
+	}
+}
+
+func tableGitHubActionsRepositoryPermissionsList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, _, err := client.Repositories.GetActionsPermissions(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	d.StreamListItem(ctx, permissions)
+
+	return nil, nil
+}
+
+func tableGitHubActionsRepositoryPermissionsSelectedActions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// The allowed actions are only listed if they are selected
+	if h.Item.(*github.ActionsPermissionsRepository).GetAllowedActions() != "selected" {
+		return nil, nil
+	}
+
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, _, err := client.Repositories.GetActionsAllowed(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return allowed, nil
+}
+
+func tableGitHubActionsRepositoryPermissionsWorkflow(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return getActionsWorkflowPermissions(ctx, client, fmt.Sprintf("repos/%s/%s", owner, repo))
+}
+
+func tableGitHubActionsRepositoryPermissionsForkPRApproval(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return getActionsForkPRApproval(ctx, client, fmt.Sprintf("repos/%s/%s", owner, repo))
+}
@@ -33,12 +33,16 @@ func tableGitHubActionsRepositoryRunner() *plugin.Table {
 			{Name: "status", Type: proto.ColumnType_STRING, Description: "The status of the runner."},
 			{Name: "busy", Type: proto.ColumnType_BOOL, Description: "Indicates whether the runner is currently in use or not."},
 			{Name: "labels", Type: proto.ColumnType_JSON, Description: "Labels represents a collection of labels attached to each runner."},
+			{Name: "label_names", Type: proto.ColumnType_JSON, Transform: transform.FromField("Labels").Transform(runnerLabelNames), Description: "The names of the labels attached to the runner."},
 		}),
 	}
 }
 
 func tableGitHubRunnerList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	orgName := d.EqualsQuals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(orgName)
@@ -90,7 +94,10 @@ func tableGitHubRunnerGet(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 	owner, repo := parseRepoFullName(orgName)
 	plugin.Logger(ctx).Trace("tableGitHubRunnerGet", "owner", owner, "repo", repo, "runnerId", runnerId)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	runner, _, err := client.Actions.GetRunner(ctx, owner, repo, runnerId)
 	if err != nil {
@@ -39,7 +39,10 @@ func tableGitHubActionsRepositorySecret() *plugin.Table {
 }
 
 func tableGitHubRepoSecretList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	orgName := d.EqualsQuals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(orgName)
@@ -90,7 +93,10 @@ func tableGitHubRepoSecretGet(ctx context.Context, d *plugin.QueryData, h *plugi
 	owner, repo := parseRepoFullName(orgName)
 	plugin.Logger(ctx).Trace("tableGitHubRepoSecretGet", "owner", owner, "repo", repo, "name", name)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	type GetResponse struct {
 		secret *github.Secret
@@ -35,7 +35,10 @@ func tableGitHubActionsRepositoryVariable() *plugin.Table {
 }
 
 func tableGitHubRepoVariableList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	repoFullName := d.EqualsQuals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(repoFullName)
@@ -86,7 +89,10 @@ func tableGitHubRepoVariableGet(ctx context.Context, d *plugin.QueryData, h *plu
 	owner, repo := parseRepoFullName(repoFullName)
 	plugin.Logger(ctx).Trace("tableGitHubRepoVariableGet", "owner", owner, "repo", repo, "name", name)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	type GetResponse struct {
 		variable *github.ActionsVariable
@@ -63,7 +63,10 @@ func tableGitHubActionsRepositoryWorkflowJob() *plugin.Table {
 }
 
 func tableGitHubRepoWorkflowJobList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	runId := d.EqualsQuals["run_id"].GetInt64Value()
 	orgName := d.EqualsQuals["repository_full_name"].GetStringValue()
@@ -126,12 +129,12 @@ func tableGitHubRepoWorkflowJobGet(ctx context.Context, d *plugin.QueryData, h *
 	owner, repo := parseRepoFullName(orgName)
 	plugin.Logger(ctx).Trace("tableGitHubRepoWorkflowJobGet", "owner", owner, "repo", repo, "jobId", jobId)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
-	var (
-		workflowJob *github.WorkflowJob
-		err         error
-	)
+	var workflowJob *github.WorkflowJob
 	workflowJob, _, err = client.Actions.GetWorkflowJobByID(ctx, owner, repo, jobId)
 	if err != nil {
 		return nil, err
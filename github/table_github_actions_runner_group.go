@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubActionsRunnerGroup() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_actions_runner_group",
+		Description: "Runner groups control which repositories and workflows of an organization can run jobs on its self-hosted runners.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.SingleColumn("organization"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsRunnerGroupList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"organization", "id"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubActionsRunnerGroupGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "organization", Type: proto.ColumnType_STRING, Transform: transform.FromQual("organization"), Description: "The login name of the organization."},
+			{Name: "id", Type: proto.ColumnType_INT, Description: "Unique ID of the runner group."},
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The name of the runner group."},
+			{Name: "visibility", Type: proto.ColumnType_STRING, Description: "The repositories that can use the runner group, either all, private or selected."},
+			{Name: "allows_public_repositories", Type: proto.ColumnType_BOOL, Description: "If true, public repositories can use the runner group."},
+			{Name: "restricted_to_workflows", Type: proto.ColumnType_BOOL, Description: "If true, only the selected workflows can use the runner group."},
+			{Name: "selected_workflows", Type: proto.ColumnType_JSON, Description: "The workflows that can use the runner group, if it is restricted to workflows, e.g. octo-org/octo-repo/.github/workflows/deploy.yaml@main."},
+
+			// Other columns
+			{Name: "default", Type: proto.ColumnType_BOOL, Description: "If true, the runner group is the default group, which new runners are added to."},
+			{Name: "inherited", Type: proto.ColumnType_BOOL, Description: "If true, the runner group is inherited from the enterprise."},
+			{Name: "workflow_restrictions_read_only", Type: proto.ColumnType_BOOL, Description: "If true, the workflow restrictions are set by the enterprise and can't be changed by the organization."},
+			{Name: "selected_repositories_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("SelectedRepositoriesURL"), Description: "The REST API URL of the repositories that can use the runner group, for selected visibility."},
+			{Name: "runners_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("RunnersURL"), Description: "The REST API URL of the runners of the runner group."},
+		}),
+	}
+}
+
+func tableGitHubActionsRunnerGroupList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, listRunnerGroups(ctx, d, client, adjustPageSize(100, d.QueryContext.Limit))
+}
+
+func tableGitHubActionsRunnerGroupGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	group, _, err := client.Actions.GetOrganizationRunnerGroup(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func listRunnerGroups(ctx context.Context, d *plugin.QueryData, client *github.Client, pageSize int) error {
+	org := d.EqualsQualString("organization")
+	opts := &github.ListOrgRunnerGroupOptions{ListOptions: github.ListOptions{PerPage: pageSize}}
+
+	for {
+		groups, resp, err := client.Actions.ListOrganizationRunnerGroups(ctx, org, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range groups.RunnerGroups {
+			if i != nil {
+				d.StreamListItem(ctx, i)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil
+}
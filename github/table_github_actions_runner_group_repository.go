@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubActionsRunnerGroupRepository() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_actions_runner_group_repository",
+		Description: "The repositories selected to use the runner groups of an organization with selected visibility.",
+		List: &plugin.ListConfig{
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "organization", Require: plugin.Required},
+				{Name: "runner_group_id", Require: plugin.Optional},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			ParentHydrate:     tableGitHubActionsRunnerGroupRepositoryGroupList,
+			Hydrate:           tableGitHubActionsRunnerGroupRepositoryList,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "organization", Type: proto.ColumnType_STRING, Transform: transform.FromQual("organization"), Description: "The login name of the organization."},
+			{Name: "runner_group_id", Type: proto.ColumnType_INT, Transform: transform.FromField("RunnerGroup.ID"), Description: "Unique ID of the runner group."},
+			{Name: "runner_group_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("RunnerGroup.Name"), Description: "The name of the runner group, unless the runner_group_id is specified."},
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Repository.FullName"), Description: "Full name of the repository that can use the runner group."},
+
+			// Other columns
+			{Name: "repository_id", Type: proto.ColumnType_INT, Transform: transform.FromField("Repository.ID"), Description: "Unique ID of the repository."},
+			{Name: "repository_private", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Repository.Private"), Description: "If true, the repository is private."},
+			{Name: "repository_html_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("Repository.HTMLURL"), Description: "The URL of the repository."},
+		}),
+	}
+}
+
+type runnerGroupRepositoryInfo struct {
+	RunnerGroup *github.RunnerGroup
+	Repository  *github.Repository
+}
+
+// tableGitHubActionsRunnerGroupRepositoryGroupList lists the runner groups
+// whose repositories are listed, which is only the group of the
+// runner_group_id qualifier if it is set.
+func tableGitHubActionsRunnerGroupRepositoryGroupList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	if d.EqualsQuals["runner_group_id"] != nil {
+		id := d.EqualsQuals["runner_group_id"].GetInt64Value()
+		d.StreamListItem(ctx, &github.RunnerGroup{ID: &id})
+		return nil, nil
+	}
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// The limit is on the repositories, so it doesn't bound the runner groups
+	// listed
+	return nil, listRunnerGroups(ctx, d, client, 100)
+}
+
+func tableGitHubActionsRunnerGroupRepositoryList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	group := h.Item.(*github.RunnerGroup)
+
+	// Only runner groups with selected visibility have repositories selected.
+	// The visibility of a group from the runner_group_id qualifier isn't known.
+	if group.Visibility != nil && group.GetVisibility() != "selected" {
+		return nil, nil
+	}
+
+	org := d.EqualsQualString("organization")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		repos, resp, err := client.Actions.ListRepositoryAccessRunnerGroup(ctx, org, group.GetID(), opts)
+		if err != nil {
+			// In the case of parent hydrate the ignore config seems to not work for the child table. So we need to handle it manually.
+			// Steampipe SDK issue ref: https://github.com/turbot/steampipe-plugin-sdk/issues/544
+			if strings.Contains(err.Error(), "404") {
+				return nil, nil
+			}
+
+			plugin.Logger(ctx).Error("github_actions_runner_group_repository.tableGitHubActionsRunnerGroupRepositoryList", "api_error", err)
+			return nil, err
+		}
+
+		for _, repo := range repos.Repositories {
+			d.StreamListItem(ctx, runnerGroupRepositoryInfo{group, repo})
+
+			// Stop if we've hit the limit set in the query context
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
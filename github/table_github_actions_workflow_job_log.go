@@ -0,0 +1,293 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+// The caps on how much of a workflow job log is read, unless the connection
+// sets workflow_job_log_max_lines or workflow_job_log_max_size_mb. The log is
+// read and streamed a line at a time.
+const (
+	defaultJobLogMaxLines = 100000
+	defaultJobLogMaxSize  = 10 << 20
+)
+
+func tableGitHubActionsWorkflowJobLog() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_actions_workflow_job_log",
+		Description: "The lines of the log of a GitHub Actions workflow job, with the step, group and annotation each belongs to.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "repository_full_name", Require: plugin.Required},
+				{Name: "job_id", Require: plugin.Required},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404", "410"}),
+			Hydrate:           tableGitHubActionsWorkflowJobLogList,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository of the workflow job."},
+			{Name: "job_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("job_id"), Description: "The unique identifier of the workflow job."},
+			{Name: "line_number", Type: proto.ColumnType_INT, Description: "The number of the line in the log, starting at 1."},
+			{Name: "step_number", Type: proto.ColumnType_INT, Description: "The number of the step the line was logged by, matched by the time the step started."},
+			{Name: "step_name", Type: proto.ColumnType_STRING, Description: "The name of the step the line was logged by."},
+			{Name: "timestamp", Type: proto.ColumnType_TIMESTAMP, Description: "Time when the line was logged."},
+			{Name: "content", Type: proto.ColumnType_STRING, Description: "The content of the line, without its timestamp and command marker."},
+			{Name: "annotation_level", Type: proto.ColumnType_STRING, Description: "The level of the annotation the line logs, either error, warning or notice."},
+
+			// Other columns
+			{Name: "command", Type: proto.ColumnType_STRING, Description: "The command marker of the line, e.g. group, endgroup, error, warning, notice, debug or command for ##[group]."},
+			{Name: "group", Type: proto.ColumnType_STRING, Description: "The name of the innermost group the line is in, as started by a ##[group] marker."},
+			{Name: "group_depth", Type: proto.ColumnType_INT, Description: "The number of groups the line is nested in, or 0 if it isn't in a group."},
+			{Name: "truncated", Type: proto.ColumnType_BOOL, Description: "If true, the row marks where the log was cut, as it is longer than the workflow_job_log_max_lines or workflow_job_log_max_size_mb config allows. It is the last row, has no content, and the remaining lines are left out."},
+		}),
+	}
+}
+
+type workflowJobLogLine struct {
+	LineNumber      int
+	StepNumber      *int64
+	StepName        *string
+	Timestamp       *time.Time
+	Content         string
+	AnnotationLevel *string
+	Command         *string
+	Group           *string
+	GroupDepth      int
+	Truncated       bool
+}
+
+func tableGitHubActionsWorkflowJobLogList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+	jobID := d.EqualsQuals["job_id"].GetInt64Value()
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	config, err := getClientConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// The steps of the job and their times, which the lines are matched to
+	job, _, err := client.Actions.GetWorkflowJobByID(ctx, owner, repo, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	logURL, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, false)
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil && resp.Request != nil {
+		logURL = resp.Request.URL.ResolveReference(logURL)
+	}
+
+	log, err := downloadWorkflowJobLog(ctx, config, logURL.String())
+	if err != nil {
+		return nil, err
+	}
+	defer log.Close()
+
+	var lineCount int
+	truncated, err := parseWorkflowJobLog(log, job.Steps, config.JobLogMaxLines, config.JobLogMaxSize, func(l *workflowJobLogLine) bool {
+		d.StreamListItem(ctx, l)
+		lineCount = l.LineNumber
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The rows already streamed can't be marked, so a last row marks that the
+	// log was cut
+	if truncated && d.RowsRemaining(ctx) != 0 {
+		d.StreamListItem(ctx, &workflowJobLogLine{LineNumber: lineCount + 1, Truncated: true})
+	}
+
+	return nil, nil
+}
+
+// downloadWorkflowJobLog requests a job log from the URL GitHub redirects to
+// and returns its body. The URL is signed, so it's requested without the
+// connection's credentials, which must not be sent to the storage host.
+func downloadWorkflowJobLog(ctx context.Context, config *clientConfig, logURL string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", logURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Transport: config.Transport}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unable to download workflow job log: %s", resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// parseWorkflowJobLog reads the lines of a job log, each of which starts
+// with an RFC 3339 timestamp, e.g.
+//
+//	2024-05-01T10:00:00.1234567Z ##[group]Run actions/checkout@v4
+//
+// and passes each to emit, until it returns false. Lines are matched to the
+// last step that started at or before they were logged, as the log doesn't
+// mark where steps start. At most maxLines lines and maxSize bytes are read,
+// less a partial last line, and the log is reported as truncated if it has
+// more.
+func parseWorkflowJobLog(r io.Reader, steps []*github.TaskStep, maxLines int, maxSize int64, emit func(*workflowJobLogLine) bool) (bool, error) {
+	if maxLines < 1 {
+		maxLines = defaultJobLogMaxLines
+	}
+
+	// Read a byte past the size cap, to tell whether the line it is in ends
+	// there
+	limited := &io.LimitedReader{R: r, N: maxSize + 1}
+	scanner := bufio.NewScanner(limited)
+	scanner.Buffer(make([]byte, 0, 64*1024), int(maxSize)+1)
+	var terminated bool
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if token != nil {
+			terminated = data[advance-1] == '\n'
+		}
+		return advance, token, err
+	})
+
+	var groups []string
+	var timestamp *time.Time
+	var count int
+
+	// A line is only emitted once the next one is read, as the last line
+	// read may be cut by the size cap
+	var pending *workflowJobLogLine
+	for scanner.Scan() {
+		if pending != nil && !emit(pending) {
+			return false, nil
+		}
+		if count == maxLines {
+			return true, nil
+		}
+		count++
+
+		raw := scanner.Text()
+		if count == 1 {
+			raw = strings.TrimPrefix(raw, "\ufeff")
+		}
+
+		line := &workflowJobLogLine{LineNumber: count}
+		content := raw
+		prefix, rest, _ := strings.Cut(raw, " ")
+		if t, err := time.Parse(time.RFC3339Nano, prefix); err == nil {
+			timestamp = &t
+			content = rest
+		}
+		// Lines without a timestamp continue the previous line
+		line.Timestamp = timestamp
+
+		var endGroup bool
+		if strings.HasPrefix(content, "##[") {
+			if end := strings.Index(content, "]"); end > 0 {
+				command := content[3:end]
+				content = content[end+1:]
+				line.Command = &command
+				switch command {
+				case "group":
+					groups = append(groups, content)
+				case "endgroup":
+					endGroup = true
+				case "error", "warning", "notice":
+					line.AnnotationLevel = &command
+				}
+			}
+		}
+		line.Content = content
+
+		if len(groups) > 0 {
+			group := groups[len(groups)-1]
+			line.Group = &group
+			line.GroupDepth = len(groups)
+		}
+		if endGroup && len(groups) > 0 {
+			groups = groups[:len(groups)-1]
+		}
+
+		if step := workflowJobLogStep(steps, timestamp); step != nil {
+			line.StepNumber = step.Number
+			line.StepName = step.Name
+		}
+
+		pending = line
+	}
+	if err := scanner.Err(); err != nil {
+		return false, err
+	}
+	if pending == nil {
+		return false, nil
+	}
+
+	// The whole log fits in the size cap
+	if limited.N > 0 {
+		emit(pending)
+		return false, nil
+	}
+
+	// The byte past the cap doesn't end the last line, so it's partial
+	if !terminated {
+		return true, nil
+	}
+
+	// The byte past the cap ends the last line, and the log is only longer
+	// if there is more to read
+	if !emit(pending) {
+		return false, nil
+	}
+	if _, err := io.ReadFull(r, make([]byte, 1)); err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// workflowJobLogStep returns the last step that started at or before the
+// time, which is truncated to the second as the times of steps are.
+func workflowJobLogStep(steps []*github.TaskStep, t *time.Time) *github.TaskStep {
+	if t == nil {
+		return nil
+	}
+	second := t.Truncate(time.Second)
+
+	var match *github.TaskStep
+	for _, s := range steps {
+		if s.StartedAt == nil || s.GetConclusion() == "skipped" {
+			continue
+		}
+		if !s.StartedAt.Time.After(second) {
+			match = s
+		}
+	}
+	return match
+}
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestParseWorkflowJobLog(t *testing.T) {
+	steps := []*github.TaskStep{
+		{Number: github.Int64(1), Name: github.String("Set up job"), StartedAt: &github.Timestamp{Time: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}},
+		{Number: github.Int64(2), Name: github.String("Run tests"), StartedAt: &github.Timestamp{Time: time.Date(2024, 5, 1, 10, 0, 5, 0, time.UTC)}},
+		{Number: github.Int64(3), Name: github.String("Lint"), Conclusion: github.String("skipped"), StartedAt: &github.Timestamp{Time: time.Date(2024, 5, 1, 10, 0, 6, 0, time.UTC)}},
+	}
+	log := "\ufeff2024-05-01T10:00:00.1000000Z ##[group]Runner Image\r\n" +
+		"2024-05-01T10:00:00.2000000Z Image: ubuntu-22.04\n" +
+		"2024-05-01T10:00:00.3000000Z ##[endgroup]\n" +
+		"2024-05-01T10:00:05.5000000Z go test ./...\n" +
+		"continued without a timestamp\n" +
+		"2024-05-01T10:00:06.9000000Z ##[error]Process completed with exit code 1.\n"
+
+	// line is the part of a parsed line the cases check
+	type line struct {
+		content    string
+		command    string
+		group      string
+		groupDepth int
+		step       int64
+		annotation string
+	}
+	all := []line{
+		{content: "Runner Image", command: "group", group: "Runner Image", groupDepth: 1, step: 1},
+		{content: "Image: ubuntu-22.04", group: "Runner Image", groupDepth: 1, step: 1},
+		{content: "", command: "endgroup", group: "Runner Image", groupDepth: 1, step: 1},
+		{content: "go test ./...", step: 2},
+		{content: "continued without a timestamp", step: 2},
+		{content: "Process completed with exit code 1.", command: "error", step: 2, annotation: "error"},
+	}
+
+	cases := []struct {
+		name     string
+		log      string
+		maxLines int
+		maxSize  int64
+		// stopAfter is how many lines are emitted before emit returns false
+		stopAfter     int
+		want          []line
+		wantTruncated bool
+	}{
+		{
+			name:     "whole log",
+			log:      log,
+			maxLines: 100,
+			maxSize:  1 << 20,
+			want:     all,
+		},
+		{
+			name:     "empty log",
+			log:      "",
+			maxLines: 100,
+			maxSize:  1 << 20,
+		},
+		{
+			name:     "exactly max lines",
+			log:      log,
+			maxLines: len(all),
+			maxSize:  1 << 20,
+			want:     all,
+		},
+		{
+			name:          "more than max lines",
+			log:           log,
+			maxLines:      2,
+			maxSize:       1 << 20,
+			want:          all[:2],
+			wantTruncated: true,
+		},
+		{
+			name:     "zero max lines",
+			log:      log,
+			maxLines: 0,
+			maxSize:  1 << 20,
+			want:     all,
+		},
+		{
+			name:          "partial line past max size",
+			log:           log,
+			maxLines:      100,
+			maxSize:       int64(strings.Index(log, "Image: ubuntu")),
+			want:          all[:1],
+			wantTruncated: true,
+		},
+		{
+			name:     "exactly max size",
+			log:      log,
+			maxLines: 100,
+			maxSize:  int64(len(log)),
+			want:     all,
+		},
+		{
+			name:     "line ending one byte past max size",
+			log:      log,
+			maxLines: 100,
+			maxSize:  int64(len(log) - 1),
+			want:     all,
+		},
+		{
+			name:          "more after a line ending one byte past max size",
+			log:           log + "2024-05-01T10:00:07.0000000Z Cleaning up\n",
+			maxLines:      100,
+			maxSize:       int64(len(log) - 1),
+			want:          all,
+			wantTruncated: true,
+		},
+		{
+			name:     "no newline at the end",
+			log:      strings.TrimSuffix(log, "\n"),
+			maxLines: 100,
+			maxSize:  1 << 20,
+			want:     all,
+		},
+		{
+			name:      "emit stops",
+			log:       log,
+			maxLines:  100,
+			maxSize:   1 << 20,
+			stopAfter: 2,
+			want:      all[:2],
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lines []*workflowJobLogLine
+			truncated, err := parseWorkflowJobLog(strings.NewReader(tc.log), steps, tc.maxLines, tc.maxSize, func(l *workflowJobLogLine) bool {
+				lines = append(lines, l)
+				return len(lines) != tc.stopAfter
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if truncated != tc.wantTruncated {
+				t.Errorf("got truncated %t, want %t", truncated, tc.wantTruncated)
+			}
+			if len(lines) != len(tc.want) {
+				t.Fatalf("got %d lines, want %d", len(lines), len(tc.want))
+			}
+			for i, l := range lines {
+				got := line{
+					content:    l.Content,
+					command:    stringValue(l.Command),
+					group:      stringValue(l.Group),
+					groupDepth: l.GroupDepth,
+					annotation: stringValue(l.AnnotationLevel),
+				}
+				if l.StepNumber != nil {
+					got.step = *l.StepNumber
+				}
+				if got != tc.want[i] {
+					t.Errorf("line %d: got %+v, want %+v", i+1, got, tc.want[i])
+				}
+				if l.LineNumber != i+1 {
+					t.Errorf("line %d: got line number %d", i+1, l.LineNumber)
+				}
+			}
+		})
+	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubAppInstallation() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_app_installation",
+		Description: "Installations of the GitHub App the connection authenticates as.",
+		List: &plugin.ListConfig{
+			Hydrate: tableGitHubAppInstallationList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.SingleColumn("id"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubAppInstallationGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_INT, Description: "The ID of the installation."},
+			{Name: "account_login", Type: proto.ColumnType_STRING, Transform: transform.FromField("Account.Login"), Description: "The login of the organization or user account the app is installed on."},
+			{Name: "account_type", Type: proto.ColumnType_STRING, Transform: transform.FromField("Account.Type"), Description: "The type of the account the app is installed on, e.g. Organization or User."},
+			{Name: "repository_selection", Type: proto.ColumnType_STRING, Description: "Whether the installation can access all repositories of the account, or only selected ones."},
+
+			// Other columns
+			{Name: "access_tokens_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("AccessTokensURL"), Description: "The API URL to create an installation access token."},
+			{Name: "account_id", Type: proto.ColumnType_INT, Transform: transform.FromField("Account.ID"), Description: "The ID of the account the app is installed on."},
+			{Name: "app_id", Type: proto.ColumnType_INT, Transform: transform.FromField("AppID"), Description: "The ID of the GitHub App."},
+			{Name: "app_slug", Type: proto.ColumnType_STRING, Description: "The URL-friendly name of the GitHub App."},
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").Transform(convertTimestamp), Description: "Time when the app was installed."},
+			{Name: "events", Type: proto.ColumnType_JSON, Description: "The webhook events the installation subscribes to."},
+			{Name: "has_multiple_single_files", Type: proto.ColumnType_BOOL, Description: "If true, the installation has access to multiple single files."},
+			{Name: "html_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("HTMLURL"), Description: "The GitHub URL of the installation."},
+			{Name: "node_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("NodeID"), Description: "The node ID of the installation."},
+			{Name: "permissions", Type: proto.ColumnType_JSON, Description: "The permissions granted to the installation."},
+			{Name: "repositories_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("RepositoriesURL"), Description: "The API URL to list the repositories the installation can access."},
+			{Name: "single_file_name", Type: proto.ColumnType_STRING, Description: "The single file the installation can access, if any."},
+			{Name: "single_file_paths", Type: proto.ColumnType_JSON, Description: "The single file paths the installation can access."},
+			{Name: "suspended_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("SuspendedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the installation was suspended."},
+			{Name: "suspended_by_login", Type: proto.ColumnType_STRING, Transform: transform.FromField("SuspendedBy.Login"), Description: "The login of the user who suspended the installation."},
+			{Name: "target_id", Type: proto.ColumnType_INT, Transform: transform.FromField("TargetID"), Description: "The ID of the account the app is installed on."},
+			{Name: "target_type", Type: proto.ColumnType_STRING, Description: "The type of the account the app is installed on."},
+			{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").Transform(convertTimestamp), Description: "Time when the installation was last updated."},
+		}),
+	}
+}
+
+func tableGitHubAppInstallationList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	installations, err := getAppInstallations(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListOptions{PerPage: 100}
+
+	limit := d.QueryContext.Limit
+	if limit != nil {
+		if *limit < int64(opts.PerPage) {
+			opts.PerPage = int(*limit)
+		}
+	}
+
+	for {
+		items, resp, err := installations.client.Apps.ListInstallations(ctx, opts)
+		if err != nil {
+			plugin.Logger(ctx).Error("github_app_installation.tableGitHubAppInstallationList", "api_error", err)
+			return nil, err
+		}
+
+		for _, i := range items {
+			if i != nil {
+				d.StreamListItem(ctx, i)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubAppInstallationGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	installations, err := getAppInstallations(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	installation, _, err := installations.client.Apps.GetInstallation(ctx, id)
+	if err != nil {
+		plugin.Logger(ctx).Error("github_app_installation.tableGitHubAppInstallationGet", "api_error", err)
+		return nil, err
+	}
+
+	return installation, nil
+}
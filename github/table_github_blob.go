@@ -45,7 +45,10 @@ type blobRow struct {
 
 func tableGitHubBlobList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	logger := plugin.Logger(ctx)
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	quals := d.EqualsQuals
 	fullName := quals["repository_full_name"].GetStringValue()
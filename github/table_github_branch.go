@@ -33,7 +33,10 @@ func tableGitHubBranch() *plugin.Table {
 }
 
 func tableGitHubBranchList(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	fullName := d.EqualsQuals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(fullName)
@@ -63,7 +66,7 @@ func tableGitHubBranchList(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_branch", &query.RateLimit))
+		logRateLimit(ctx, d, "github_branch", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_branch", "api_error", err)
 			return nil, err
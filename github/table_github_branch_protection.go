@@ -64,7 +64,10 @@ func tableGitHubBranchProtection() *plugin.Table {
 }
 
 func tableGitHubRepositoryBranchProtectionList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	fullName := d.EqualsQuals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(fullName)
@@ -91,7 +94,7 @@ func tableGitHubRepositoryBranchProtectionList(ctx context.Context, d *plugin.Qu
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_branch_protection", &query.RateLimit))
+		logRateLimit(ctx, d, "github_branch_protection", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_branch_protection", "api_error", err)
 			return nil, err
@@ -139,7 +142,10 @@ func tableGitHubRepositoryBranchProtectionList(ctx context.Context, d *plugin.Qu
 }
 
 func tableGitHubRepositoryBranchProtectionGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	nodeId := d.EqualsQuals["node_id"].GetStringValue()
 
@@ -155,8 +161,8 @@ func tableGitHubRepositoryBranchProtectionGet(ctx context.Context, d *plugin.Que
 	}
 	appendBranchProtectionRuleColumnIncludes(&variables, d.QueryContext.Columns)
 
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_branch_protection", &query.RateLimit))
+	err = client.Query(ctx, &query, variables)
+	logRateLimit(ctx, d, "github_branch_protection", &query.RateLimit)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_branch_protection", "api_error", err)
 		return nil, err
@@ -204,7 +210,7 @@ func branchProtectionGetPushAllowances(ctx context.Context, d *plugin.QueryData,
 
 	for {
 		err := client.Query(ctx, &query, vars)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_branch_protection", &query.RateLimit))
+		logRateLimit(ctx, d, "github_branch_protection", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_branch_protection", "api_error", err)
 			return err
@@ -241,7 +247,7 @@ func branchProtectionGetBypassForcePushAllowances(ctx context.Context, d *plugin
 
 	for {
 		err := client.Query(ctx, &query, vars)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_branch_protection", &query.RateLimit))
+		logRateLimit(ctx, d, "github_branch_protection", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_branch_protection", "api_error", err)
 			return err
@@ -278,7 +284,7 @@ func branchProtectionGetBypassPullRequestAllowances(ctx context.Context, d *plug
 
 	for {
 		err := client.Query(ctx, &query, vars)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_branch_protection", &query.RateLimit))
+		logRateLimit(ctx, d, "github_branch_protection", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_branch_protection", "api_error", err)
 			return err
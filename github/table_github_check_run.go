@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubCheckRun() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_check_run",
+		Description: "Check runs are the individual checks, such as builds, tests and linters, run against a commit.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "repository_full_name", Require: plugin.Required},
+				{Name: "ref", Require: plugin.Required},
+				{Name: "name", Require: plugin.Optional},
+				{Name: "status", Require: plugin.Optional},
+				{Name: "app_id", Require: plugin.Optional},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404", "422"}),
+			Hydrate:           tableGitHubCheckRunList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "id"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubCheckRunGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository that contains the check run."},
+			{Name: "ref", Type: proto.ColumnType_STRING, Transform: transform.FromQual("ref"), Description: "The commit SHA, branch or tag name the check runs are listed for."},
+			{Name: "id", Type: proto.ColumnType_INT, Description: "The unique identifier of the check run."},
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The name of the check run."},
+			{Name: "app_slug", Type: proto.ColumnType_STRING, Transform: transform.FromField("App.Slug"), Description: "The slug of the GitHub App that created the check run."},
+			{Name: "status", Type: proto.ColumnType_STRING, Description: "The status of the check run, e.g. queued, in_progress or completed."},
+			{Name: "conclusion", Type: proto.ColumnType_STRING, Description: "The conclusion of the check run, e.g. success, failure, neutral, cancelled, skipped, timed_out or action_required."},
+			{Name: "started_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("StartedAt").Transform(convertTimestamp), Description: "Time when the check run started."},
+			{Name: "completed_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CompletedAt").Transform(convertTimestamp), Description: "Time when the check run completed."},
+			{Name: "output_title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Output.Title"), Description: "The title of the output of the check run."},
+			{Name: "output_summary", Type: proto.ColumnType_STRING, Transform: transform.FromField("Output.Summary"), Description: "The summary of the output of the check run."},
+
+			// Other columns
+			{Name: "app_id", Type: proto.ColumnType_INT, Transform: transform.FromField("App.ID"), Description: "The ID of the GitHub App that created the check run."},
+			{Name: "app_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("App.Name"), Description: "The name of the GitHub App that created the check run."},
+			{Name: "check_suite_id", Type: proto.ColumnType_INT, Transform: transform.FromField("CheckSuite.ID"), Description: "The ID of the check suite the check run belongs to."},
+			{Name: "head_sha", Type: proto.ColumnType_STRING, Transform: transform.FromField("HeadSHA"), Description: "The SHA of the commit the check run is for."},
+			{Name: "external_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ExternalID"), Description: "The ID the check run has in the system of the GitHub App."},
+			{Name: "output_text", Type: proto.ColumnType_STRING, Transform: transform.FromField("Output.Text"), Description: "The details of the output of the check run."},
+			{Name: "output_annotations_count", Type: proto.ColumnType_INT, Transform: transform.FromField("Output.AnnotationsCount"), Description: "The number of annotations of the check run."},
+			{Name: "pull_requests", Type: proto.ColumnType_JSON, Description: "The pull requests the check run is for."},
+			{Name: "details_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("DetailsURL"), Description: "The URL of the details of the check run on the site of the GitHub App."},
+			{Name: "html_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("HTMLURL"), Description: "The URL of the check run on GitHub."},
+			{Name: "node_id", Type: proto.ColumnType_STRING, Description: "The node ID of the check run."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "The API URL of the check run."},
+		}),
+	}
+}
+
+func tableGitHubCheckRunList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+	ref := d.EqualsQualString("ref")
+
+	// List every run of each check, not only the latest, so reruns of flaky
+	// checks show up
+	opts := &github.ListCheckRunsOptions{
+		Filter:      github.String("all"),
+		ListOptions: github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)},
+	}
+	if name := d.EqualsQualString("name"); name != "" {
+		opts.CheckName = &name
+	}
+	if status := d.EqualsQualString("status"); status != "" {
+		opts.Status = &status
+	}
+	if d.EqualsQuals["app_id"] != nil {
+		opts.AppID = github.Int64(d.EqualsQuals["app_id"].GetInt64Value())
+	}
+
+	for {
+		result, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, run := range result.CheckRuns {
+			if run != nil {
+				d.StreamListItem(ctx, run)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubCheckRunGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQuals["id"].GetInt64Value()
+	fullName := d.EqualsQualString("repository_full_name")
+
+	// Empty check for the parameters
+	if id == 0 || fullName == "" {
+		return nil, nil
+	}
+
+	owner, repo := parseRepoFullName(fullName)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	run, _, err := client.Checks.GetCheckRun(ctx, owner, repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
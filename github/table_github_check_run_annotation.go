@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubCheckRunAnnotation() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_check_run_annotation",
+		Description: "Annotations of a check run point at the lines of code its errors, warnings and notices are about.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "check_run_id"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubCheckRunAnnotationList,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository that contains the check run."},
+			{Name: "check_run_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("check_run_id"), Description: "The ID of the check run the annotation belongs to."},
+			{Name: "path", Type: proto.ColumnType_STRING, Description: "The path of the file the annotation is about."},
+			{Name: "start_line", Type: proto.ColumnType_INT, Description: "The first line the annotation is about."},
+			{Name: "end_line", Type: proto.ColumnType_INT, Description: "The last line the annotation is about."},
+			{Name: "annotation_level", Type: proto.ColumnType_STRING, Description: "The level of the annotation, one of notice, warning or failure."},
+			{Name: "message", Type: proto.ColumnType_STRING, Description: "The message of the annotation."},
+
+			// Other columns
+			{Name: "title", Type: proto.ColumnType_STRING, Description: "The title of the annotation."},
+			{Name: "start_column", Type: proto.ColumnType_INT, Description: "The first column the annotation is about, if it's about a single line."},
+			{Name: "end_column", Type: proto.ColumnType_INT, Description: "The last column the annotation is about, if it's about a single line."},
+			{Name: "raw_details", Type: proto.ColumnType_STRING, Description: "The details of the annotation."},
+		}),
+	}
+}
+
+func tableGitHubCheckRunAnnotationList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+	checkRunID := d.EqualsQuals["check_run_id"].GetInt64Value()
+	opts := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		annotations, resp, err := client.Checks.ListCheckRunAnnotations(ctx, owner, repo, checkRunID, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, annotation := range annotations {
+			if annotation != nil {
+				d.StreamListItem(ctx, annotation)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,118 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubCheckSuite() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_check_suite",
+		Description: "Check suites group the check runs a GitHub App creates for a commit.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "repository_full_name", Require: plugin.Required},
+				{Name: "ref", Require: plugin.Required},
+				{Name: "app_id", Require: plugin.Optional},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404", "422"}),
+			Hydrate:           tableGitHubCheckSuiteList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "id"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubCheckSuiteGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository that contains the check suite."},
+			{Name: "ref", Type: proto.ColumnType_STRING, Transform: transform.FromQual("ref"), Description: "The commit SHA, branch or tag name the check suites are listed for."},
+			{Name: "id", Type: proto.ColumnType_INT, Description: "The unique identifier of the check suite."},
+			{Name: "app_slug", Type: proto.ColumnType_STRING, Transform: transform.FromField("App.Slug"), Description: "The slug of the GitHub App the check suite belongs to."},
+			{Name: "status", Type: proto.ColumnType_STRING, Description: "The status of the check suite, e.g. queued, in_progress or completed."},
+			{Name: "conclusion", Type: proto.ColumnType_STRING, Description: "The conclusion of the check suite, e.g. success, failure, neutral, cancelled, skipped, timed_out or action_required."},
+			{Name: "head_sha", Type: proto.ColumnType_STRING, Transform: transform.FromField("HeadSHA"), Description: "The SHA of the commit the check suite is for."},
+
+			// Other columns
+			{Name: "app_id", Type: proto.ColumnType_INT, Transform: transform.FromField("App.ID"), Description: "The ID of the GitHub App the check suite belongs to."},
+			{Name: "app_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("App.Name"), Description: "The name of the GitHub App the check suite belongs to."},
+			{Name: "head_branch", Type: proto.ColumnType_STRING, Description: "The branch the commit was pushed to."},
+			{Name: "before_sha", Type: proto.ColumnType_STRING, Transform: transform.FromField("BeforeSHA"), Description: "The SHA of the commit the branch pointed to before the push."},
+			{Name: "after_sha", Type: proto.ColumnType_STRING, Transform: transform.FromField("AfterSHA"), Description: "The SHA of the commit the branch pointed to after the push."},
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").Transform(convertTimestamp), Description: "Time when the check suite was created."},
+			{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").Transform(convertTimestamp), Description: "Time when the check suite was last updated."},
+			{Name: "pull_requests", Type: proto.ColumnType_JSON, Description: "The pull requests the check suite is for."},
+			{Name: "node_id", Type: proto.ColumnType_STRING, Description: "The node ID of the check suite."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "The API URL of the check suite."},
+		}),
+	}
+}
+
+func tableGitHubCheckSuiteList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+	ref := d.EqualsQualString("ref")
+	opts := &github.ListCheckSuiteOptions{ListOptions: github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}}
+	if d.EqualsQuals["app_id"] != nil {
+		appID := int(d.EqualsQuals["app_id"].GetInt64Value())
+		opts.AppID = &appID
+	}
+
+	for {
+		result, resp, err := client.Checks.ListCheckSuitesForRef(ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, suite := range result.CheckSuites {
+			if suite != nil {
+				d.StreamListItem(ctx, suite)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubCheckSuiteGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQuals["id"].GetInt64Value()
+	fullName := d.EqualsQualString("repository_full_name")
+
+	// Empty check for the parameters
+	if id == 0 || fullName == "" {
+		return nil, nil
+	}
+
+	owner, repo := parseRepoFullName(fullName)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	suite, _, err := client.Checks.GetCheckSuite(ctx, owner, repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return suite, nil
+}
@@ -66,7 +66,10 @@ func tableGitHubCodeOwnerList(ctx context.Context, d *plugin.QueryData, h *plugi
 		var fileContent *github.RepositoryContent
 		var err error
 
-		client := connect(ctx, d)
+		client, err := connect(ctx, d)
+		if err != nil {
+			return nil, err
+		}
 		opt := &github.RepositoryContentGetOptions{}
 		// If a repository has multiple CODEOWNERS files, GitHub uses the following
 		// precedence: .github/CODEOWNERS, CODEOWNERS, docs/CODEOWNERS
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubCodeScanningAnalysis() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_code_scanning_analysis",
+		Description: "Code scanning analyses are the results of running a code scanning tool against a commit of a repository.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "repository_full_name", Require: plugin.Required},
+				{Name: "ref", Require: plugin.Optional},
+				{Name: "sarif_id", Require: plugin.Optional},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubCodeScanningAnalysisList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "id"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubCodeScanningAnalysisGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository that was analyzed."},
+			{Name: "id", Type: proto.ColumnType_INT, Description: "Unique ID of the analysis."},
+			{Name: "ref", Type: proto.ColumnType_STRING, Transform: transform.FromField("Ref"), Description: "The Git reference that was analyzed, e.g. refs/heads/main."},
+			{Name: "commit_sha", Type: proto.ColumnType_STRING, Transform: transform.FromField("CommitSHA"), Description: "The SHA of the commit that was analyzed."},
+			{Name: "tool_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Tool.Name"), Description: "The name of the tool that ran the analysis, e.g. CodeQL."},
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").Transform(convertTimestamp), Description: "Time when the analysis was created."},
+
+			// Other columns
+			{Name: "analysis_key", Type: proto.ColumnType_STRING, Description: "The identifier of the configuration, e.g. the workflow and job, that ran the analysis."},
+			{Name: "category", Type: proto.ColumnType_STRING, Description: "The category that identifies the analysis when several are run on the same commit."},
+			{Name: "environment", Type: proto.ColumnType_STRING, Description: "The environment of the analysis, as a JSON encoded string."},
+			{Name: "error", Type: proto.ColumnType_STRING, Description: "The error of the analysis, if it failed."},
+			{Name: "warning", Type: proto.ColumnType_STRING, Description: "The warning of the analysis, if any."},
+			{Name: "results_count", Type: proto.ColumnType_INT, Description: "The number of results found by the analysis."},
+			{Name: "rules_count", Type: proto.ColumnType_INT, Description: "The number of rules used by the analysis."},
+			{Name: "sarif_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("SarifID"), Description: "The ID of the SARIF upload the analysis belongs to."},
+			{Name: "tool_guid", Type: proto.ColumnType_STRING, Transform: transform.FromField("Tool.GUID"), Description: "The GUID of the tool that ran the analysis."},
+			{Name: "tool_version", Type: proto.ColumnType_STRING, Transform: transform.FromField("Tool.Version"), Description: "The version of the tool that ran the analysis."},
+			{Name: "deletable", Type: proto.ColumnType_BOOL, Description: "If true, the analysis can be deleted."},
+			{Name: "url", Type: proto.ColumnType_STRING, Description: "The REST API URL of the analysis."},
+		}),
+	}
+}
+
+func tableGitHubCodeScanningAnalysisList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	opts := &github.AnalysesListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	if ref := d.EqualsQualString("ref"); ref != "" {
+		opts.Ref = &ref
+	}
+	if sarifID := d.EqualsQualString("sarif_id"); sarifID != "" {
+		opts.SarifID = &sarifID
+	}
+	opts.PerPage = adjustPageSize(opts.PerPage, d.QueryContext.Limit)
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		analyses, resp, err := client.CodeScanning.ListAnalysesForRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range analyses {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubCodeScanningAnalysisGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQuals["id"].GetInt64Value()
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis, _, err := client.CodeScanning.GetAnalysis(ctx, owner, repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return analysis, nil
+}
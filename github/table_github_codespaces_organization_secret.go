@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+)
+
+func tableGitHubCodespacesOrganizationSecret() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_codespaces_organization_secret",
+		Description: "Codespaces secrets are encrypted environment variables created in an organization for use in codespaces.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.SingleColumn("organization"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubCodespacesOrganizationSecretList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"organization", "name"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubCodespacesOrganizationSecretGet,
+		},
+		Columns: commonColumns(gitHubOrganizationSecretColumns()),
+	}
+}
+
+func tableGitHubCodespacesOrganizationSecretList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, listOrganizationSecrets(ctx, d, client.Codespaces.ListOrgSecrets, &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)})
+}
+
+func tableGitHubCodespacesOrganizationSecretGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return getOrganizationSecret(ctx, d, client.Codespaces.GetOrgSecret)
+}
@@ -2,7 +2,7 @@ package github
 
 import (
 	"context"
-	"time"
+	"strings"
 
 	"github.com/shurcooL/githubv4"
 	"github.com/turbot/steampipe-plugin-github/github/models"
@@ -20,7 +20,12 @@ func tableGitHubCommit() *plugin.Table {
 			ShouldIgnoreError: isNotFoundError([]string{"404"}),
 			KeyColumns: []*plugin.KeyColumn{
 				{Name: "repository_full_name", Require: plugin.Required},
+				{Name: "ref", Require: plugin.Optional},
+				{Name: "path", Require: plugin.Optional},
+				{Name: "author_login", Require: plugin.Optional},
+				{Name: "author_email", Require: plugin.Optional},
 				{Name: "authored_date", Require: plugin.Optional, Operators: []string{">", ">=", "=", "<", "<="}},
+				{Name: "committed_date", Require: plugin.Optional, Operators: []string{">", ">=", "=", "<", "<="}},
 			},
 			Hydrate: tableGitHubCommitList,
 		},
@@ -35,6 +40,7 @@ func tableGitHubCommit() *plugin.Table {
 			{Name: "short_sha", Type: proto.ColumnType_STRING, Hydrate: commitHydrateShortSha, Transform: transform.FromValue(), Description: "Short SHA of the commit."},
 			{Name: "message", Type: proto.ColumnType_STRING, Hydrate: commitHydrateMessage, Transform: transform.FromValue(), Description: "Commit message."},
 			{Name: "author_login", Type: proto.ColumnType_STRING, Hydrate: commitHydrateAuthorLogin, Transform: transform.FromValue(), Description: "The login name of the author of the commit."},
+			{Name: "author_email", Type: proto.ColumnType_STRING, Hydrate: commitHydrateAuthorEmail, Transform: transform.FromValue(), Description: "The email of the author of the commit."},
 			{Name: "authored_date", Type: proto.ColumnType_TIMESTAMP, Hydrate: commitHydrateAuthoredDate, Transform: transform.FromValue().NullIfZero().Transform(convertTimestamp), Description: "Timestamp when the author made this commit."},
 			{Name: "author", Type: proto.ColumnType_JSON, Hydrate: commitHydrateAuthor, Transform: transform.FromValue().NullIfZero(), Description: "The commit author."},
 			{Name: "committer_login", Type: proto.ColumnType_STRING, Hydrate: commitHydrateCommitterLogin, Transform: transform.FromValue(), Description: "The login name of the committer."},
@@ -56,6 +62,8 @@ func tableGitHubCommit() *plugin.Table {
 			{Name: "url", Type: proto.ColumnType_STRING, Hydrate: commitHydrateUrl, Transform: transform.FromValue(), Description: "URL of the commit."},
 			{Name: "node_id", Type: proto.ColumnType_STRING, Hydrate: commitHydrateNodeId, Transform: transform.FromValue(), Description: "The node ID of the commit."},
 			{Name: "message_headline", Type: proto.ColumnType_STRING, Hydrate: commitHydrateMessageHeadline, Transform: transform.FromValue(), Description: "The Git commit message headline."},
+			{Name: "ref", Type: proto.ColumnType_STRING, Transform: transform.FromQual("ref"), Description: "The branch, tag or commit SHA the history of commits is listed from, instead of the default branch. Only set when used in the where clause."},
+			{Name: "path", Type: proto.ColumnType_STRING, Transform: transform.FromQual("path"), Description: "The path of a file or directory, to list the commits that changed it. Only set when used in the where clause."},
 		}),
 	}
 }
@@ -72,60 +80,128 @@ func tableGitHubCommitList(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		"cursor":   (*githubv4.String)(nil),
 		"since":    (*githubv4.GitTimestamp)(nil),
 		"until":    (*githubv4.GitTimestamp)(nil),
+		"ref":      (*githubv4.String)(nil),
+		"path":     (*githubv4.String)(nil),
+		"author":   (*githubv4.CommitAuthor)(nil),
 	}
 
-	if d.Quals["authored_date"] != nil {
-		for _, q := range d.Quals["authored_date"].Quals {
-			givenTime := q.Value.GetTimestampValue().AsTime()
-			beforeTime := givenTime.Add(time.Duration(-1) * time.Second)
-			afterTime := givenTime.Add(time.Second * 1)
+	// The history is that of the default branch, unless a ref is given
+	hasRef := d.EqualsQuals["ref"] != nil
+	variables["hasRef"] = githubv4.Boolean(hasRef)
+	if hasRef {
+		variables["ref"] = githubv4.String(d.EqualsQualString("ref"))
+	}
+
+	if d.EqualsQuals["path"] != nil {
+		variables["path"] = githubv4.String(d.EqualsQualString("path"))
+	}
+
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// GitHub filters commits by the ID of their author's user, or by their
+	// author's emails
+	if d.EqualsQuals["author_login"] != nil {
+		var userQuery struct {
+			RateLimit models.RateLimit
+			User      struct {
+				ID githubv4.ID
+			} `graphql:"user(login: $login)"`
+		}
+		err := client.Query(ctx, &userQuery, map[string]interface{}{"login": githubv4.String(d.EqualsQualString("author_login"))})
+		logRateLimit(ctx, d, "github_commit", &userQuery.RateLimit)
+		if err != nil {
+			plugin.Logger(ctx).Error("github_commit", "api_error", err)
+			if strings.Contains(err.Error(), "Could not resolve to a User with the login of") {
+				return nil, nil
+			}
+			return nil, err
+		}
+		variables["author"] = githubv4.CommitAuthor{ID: &userQuery.User.ID}
+	} else if d.EqualsQuals["author_email"] != nil {
+		variables["author"] = githubv4.CommitAuthor{Emails: &[]githubv4.String{githubv4.String(d.EqualsQualString("author_email"))}}
+	}
+
+	// GitHub filters commits by the date they were committed, with since and
+	// until. The dates they were authored are used if there is no qual on the
+	// committed date, but only as a lower bound, as commits are committed after
+	// they're authored, while a commit authored before a date may have been
+	// committed after it. Strict and inclusive bounds are passed alike, and
+	// Postgres drops the commits on a strict bound.
+	dateColumn := "authored_date"
+	if d.Quals["committed_date"] != nil {
+		dateColumn = "committed_date"
+	}
+	if d.Quals[dateColumn] != nil {
+		for _, q := range d.Quals[dateColumn].Quals {
+			givenTime := githubv4.GitTimestamp{Time: q.Value.GetTimestampValue().AsTime()}
 
 			switch q.Operator {
-			case ">":
-				variables["since"] = githubv4.GitTimestamp{Time: afterTime}
-			case ">=":
-				variables["since"] = githubv4.GitTimestamp{Time: givenTime}
+			case ">", ">=":
+				variables["since"] = givenTime
 			case "=":
-				variables["since"] = githubv4.GitTimestamp{Time: givenTime}
-				variables["until"] = githubv4.GitTimestamp{Time: givenTime}
-			case "<=":
-				variables["until"] = githubv4.GitTimestamp{Time: givenTime}
-			case "<":
-				variables["until"] = githubv4.GitTimestamp{Time: beforeTime}
+				variables["since"] = givenTime
+				if dateColumn == "committed_date" {
+					variables["until"] = givenTime
+				}
+			case "<", "<=":
+				if dateColumn == "committed_date" {
+					variables["until"] = givenTime
+				}
 			}
 		}
 	}
 
+	type commitHistory struct {
+		History struct {
+			TotalCount int
+			PageInfo   models.PageInfo
+			Nodes      []models.Commit
+		} `graphql:"history(first: $pageSize, after: $cursor, since: $since, until: $until, path: $path, author: $author)"`
+	}
 	var query struct {
 		RateLimit  models.RateLimit
 		Repository struct {
 			DefaultBranchRef struct {
 				Target struct {
-					Commit struct {
-						History struct {
-							TotalCount int
-							PageInfo   models.PageInfo
-							Nodes      []models.Commit
-						} `graphql:"history(first: $pageSize, after: $cursor, since: $since, until: $until)"`
-					} `graphql:"... on Commit"`
+					Commit commitHistory `graphql:"... on Commit"`
 				}
-			}
+			} `graphql:"defaultBranchRef @skip(if: $hasRef)"`
+			// A ref resolves to a commit, or to an annotated tag that
+			// points at one
+			Object struct {
+				Type   string        `graphql:"__typename"`
+				Commit commitHistory `graphql:"... on Commit"`
+				Tag    struct {
+					Target struct {
+						Commit commitHistory `graphql:"... on Commit"`
+					}
+				} `graphql:"... on Tag"`
+			} `graphql:"object(expression: $ref) @include(if: $hasRef)"`
 		} `graphql:"repository(owner: $owner, name: $name)"`
 	}
 
 	appendCommitColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
-
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_commit", &query.RateLimit))
+		logRateLimit(ctx, d, "github_commit", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_commit", "api_error", err)
 			return nil, err
 		}
 
-		for _, commit := range query.Repository.DefaultBranchRef.Target.Commit.History.Nodes {
+		history := query.Repository.DefaultBranchRef.Target.Commit.History
+		if hasRef {
+			history = query.Repository.Object.Commit.History
+			if query.Repository.Object.Type == "Tag" {
+				history = query.Repository.Object.Tag.Target.Commit.History
+			}
+		}
+
+		for _, commit := range history.Nodes {
 			d.StreamListItem(ctx, commit)
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
@@ -134,10 +210,10 @@ func tableGitHubCommitList(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 			}
 		}
 
-		if !query.Repository.DefaultBranchRef.Target.Commit.History.PageInfo.HasNextPage {
+		if !history.PageInfo.HasNextPage {
 			break
 		}
-		variables["cursor"] = githubv4.NewString(query.Repository.DefaultBranchRef.Target.Commit.History.PageInfo.EndCursor)
+		variables["cursor"] = githubv4.NewString(history.PageInfo.EndCursor)
 	}
 
 	return nil, nil
@@ -165,10 +241,13 @@ func tableGitHubCommitGet(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 	}
 	appendCommitColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_commit", &query.RateLimit))
+	err = client.Query(ctx, &query, variables)
+	logRateLimit(ctx, d, "github_commit", &query.RateLimit)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_commit", "api_error", err)
 		return nil, err
@@ -0,0 +1,80 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubCommitStatus() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_commit_status",
+		Description: "Commit statuses are the states external services, such as CI systems, report for a commit under a context.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "ref"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404", "422"}),
+			Hydrate:           tableGitHubCommitStatusList,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository that contains the commit."},
+			{Name: "ref", Type: proto.ColumnType_STRING, Transform: transform.FromQual("ref"), Description: "The commit SHA, branch or tag name the statuses are listed for."},
+			{Name: "id", Type: proto.ColumnType_INT, Description: "The unique identifier of the status."},
+			{Name: "context", Type: proto.ColumnType_STRING, Description: "The label that tells the status apart from those of other services, e.g. ci/circleci."},
+			{Name: "state", Type: proto.ColumnType_STRING, Description: "The state of the status, one of error, failure, pending or success."},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "The short description of the status."},
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").Transform(convertTimestamp), Description: "Time when the status was created."},
+
+			// Other columns
+			{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").Transform(convertTimestamp), Description: "Time when the status was last updated."},
+			{Name: "creator_login", Type: proto.ColumnType_STRING, Transform: transform.FromField("Creator.Login"), Description: "The login of the user who created the status."},
+			{Name: "target_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("TargetURL"), Description: "The URL of the details of the status."},
+			{Name: "avatar_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("AvatarURL"), Description: "The URL of the avatar of the status."},
+			{Name: "node_id", Type: proto.ColumnType_STRING, Description: "The node ID of the status."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "The API URL of the status."},
+		}),
+	}
+}
+
+func tableGitHubCommitStatusList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+	ref := d.EqualsQualString("ref")
+	opts := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	// Statuses are listed newest first, including those a later status of
+	// the same context replaced
+	for {
+		statuses, resp, err := client.Repositories.ListStatuses(ctx, owner, repo, ref, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, status := range statuses {
+			if status != nil {
+				d.StreamListItem(ctx, status)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
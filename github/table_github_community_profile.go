@@ -49,10 +49,13 @@ func tableGitHubCommunityProfileList(ctx context.Context, d *plugin.QueryData, _
 	}
 	appendCommunityProfileColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_community_profile", &query.RateLimit))
+	err = client.Query(ctx, &query, variables)
+	logRateLimit(ctx, d, "github_community_profile", &query.RateLimit)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_community_profile", "api_error", err)
 		return nil, err
@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+)
+
+func tableGitHubDependabotOrganizationSecret() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_dependabot_organization_secret",
+		Description: "Dependabot secrets are encrypted credentials created in an organization for Dependabot to access private registries with.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.SingleColumn("organization"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubDependabotOrganizationSecretList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"organization", "name"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubDependabotOrganizationSecretGet,
+		},
+		Columns: commonColumns(gitHubOrganizationSecretColumns()),
+	}
+}
+
+func tableGitHubDependabotOrganizationSecretList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, listOrganizationSecrets(ctx, d, client.Dependabot.ListOrgSecrets, &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)})
+}
+
+func tableGitHubDependabotOrganizationSecretGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return getOrganizationSecret(ctx, d, client.Dependabot.GetOrgSecret)
+}
@@ -0,0 +1,91 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubDependabotRepositorySecret() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_dependabot_repository_secret",
+		Description: "Dependabot secrets are encrypted credentials created in a repository for Dependabot to access private registries with.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.SingleColumn("repository_full_name"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubDependabotRepositorySecretList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "name"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubDependabotRepositorySecretGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository that contains the secret."},
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The name of the secret."},
+			{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the secret was last updated."},
+
+			// Other columns
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the secret was created."},
+		}),
+	}
+}
+
+func tableGitHubDependabotRepositorySecretList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		secrets, resp, err := client.Dependabot.ListRepoSecrets(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range secrets.Secrets {
+			if i != nil {
+				d.StreamListItem(ctx, i)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubDependabotRepositorySecretGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+	name := d.EqualsQualString("name")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, _, err := client.Dependabot.GetRepoSecret(ctx, owner, repo, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
@@ -45,7 +45,10 @@ func tableGitHubGist() *plugin.Table {
 }
 
 func tableGitHubGistList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	var id string
 	if h.Item != nil {
@@ -30,7 +30,10 @@ func tableGitHubGitignore() *plugin.Table {
 }
 
 func tableGitHubGitignoreList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	gitIgnores, _, err := client.Gitignores.List(ctx)
 	if err != nil {
@@ -64,7 +67,10 @@ func tableGitHubGitignoreGetData(ctx context.Context, d *plugin.QueryData, h *pl
 		return nil, nil
 	}
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	gitIgnore, _, err := client.Gitignores.Get(ctx, name)
 	if err != nil {
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/shurcooL/githubv4"
@@ -16,6 +17,9 @@ import (
 func gitHubIssueColumns() []*plugin.Column {
 	tableCols := []*plugin.Column{
 		{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "The full name of the repository (login/repo-name)."},
+		{Name: "assignee_login", Type: proto.ColumnType_STRING, Transform: transform.FromQual("assignee_login"), Description: "The login of a user the issue is assigned to, or * for issues assigned to anyone. Only set when used in the where clause."},
+		{Name: "mentioned_login", Type: proto.ColumnType_STRING, Transform: transform.FromQual("mentioned_login"), Description: "The login of a user mentioned in the issue. Only set when used in the where clause."},
+		{Name: "milestone_number", Type: proto.ColumnType_INT, Hydrate: issueHydrateMilestone, Transform: transform.FromField("Number").NullIfZero(), Description: "The number of the milestone associated with the issue."},
 	}
 
 	return append(tableCols, sharedIssueColumns()...)
@@ -85,10 +89,27 @@ func tableGitHubIssue() *plugin.Table {
 					Name:    "state",
 					Require: plugin.Optional,
 				},
+				{
+					Name:    "assignee_login",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "mentioned_login",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "milestone_number",
+					Require: plugin.Optional,
+				},
+				{
+					Name:      "labels",
+					Require:   plugin.Optional,
+					Operators: []string{"?", "@>"},
+				},
 				{
 					Name:      "updated_at",
 					Require:   plugin.Optional,
-					Operators: []string{">", ">="},
+					Operators: []string{">", ">=", "=", "<=", "<"},
 				},
 			},
 			ShouldIgnoreError: isNotFoundError([]string{"404"}),
@@ -132,19 +153,53 @@ func tableGitHubRepositoryIssueList(ctx context.Context, d *plugin.QueryData, h
 		filters.CreatedBy = githubv4.NewString(githubv4.String(author))
 	}
 
+	if quals["assignee_login"] != nil {
+		assignee := quals["assignee_login"].GetStringValue()
+		filters.Assignee = githubv4.NewString(githubv4.String(assignee))
+	}
+
+	if quals["mentioned_login"] != nil {
+		mentioned := quals["mentioned_login"].GetStringValue()
+		filters.Mentioned = githubv4.NewString(githubv4.String(mentioned))
+	}
+
+	if quals["milestone_number"] != nil {
+		milestone := strconv.FormatInt(quals["milestone_number"].GetInt64Value(), 10)
+		filters.MilestoneNumber = githubv4.NewString(githubv4.String(milestone))
+	}
+
+	if d.Quals["labels"] != nil {
+		if label := labelFilter(d.Quals["labels"].Quals); label != "" {
+			filters.Labels = &[]githubv4.String{githubv4.String(label)}
+		}
+	}
+
+	// GitHub only filters on the time issues were last updated from, so an
+	// upper bound is applied by listing issues in the order they were updated
+	// and stopping at the first one updated after it. Strict and inclusive
+	// bounds are passed alike, as the times in quals may have fractions of a
+	// second that GitHub's don't, and Postgres drops the issues on a strict
+	// bound.
+	order := githubv4.IssueOrder{Field: githubv4.IssueOrderFieldCreatedAt, Direction: githubv4.OrderDirectionAsc}
+	var until *time.Time
 	if d.Quals["updated_at"] != nil {
 		for _, q := range d.Quals["updated_at"].Quals {
 			givenTime := q.Value.GetTimestampValue().AsTime()
-			afterTime := givenTime.Add(time.Second * 1)
 
 			switch q.Operator {
-			case ">":
-				filters.Since = githubv4.NewDateTime(githubv4.DateTime{Time: afterTime})
-			case ">=":
+			case ">", ">=":
+				filters.Since = githubv4.NewDateTime(githubv4.DateTime{Time: givenTime})
+			case "=":
 				filters.Since = githubv4.NewDateTime(githubv4.DateTime{Time: givenTime})
+				until = &givenTime
+			case "<", "<=":
+				until = &givenTime
 			}
 		}
 	}
+	if until != nil {
+		order.Field = githubv4.IssueOrderFieldUpdatedAt
+	}
 
 	var query struct {
 		RateLimit  models.RateLimit
@@ -153,7 +208,7 @@ func tableGitHubRepositoryIssueList(ctx context.Context, d *plugin.QueryData, h
 				PageInfo   models.PageInfo
 				TotalCount int
 				Nodes      []models.Issue
-			} `graphql:"issues(first: $pageSize, after: $cursor, filterBy: $filters)"`
+			} `graphql:"issues(first: $pageSize, after: $cursor, filterBy: $filters, orderBy: $orderBy)"`
 		} `graphql:"repository(owner: $owner, name: $name)"`
 	}
 
@@ -163,21 +218,33 @@ func tableGitHubRepositoryIssueList(ctx context.Context, d *plugin.QueryData, h
 		"pageSize": githubv4.Int(pageSize),
 		"cursor":   (*githubv4.String)(nil),
 		"filters":  filters,
+		"orderBy":  order,
 	}
 	appendIssueColumnIncludes(&variables, d.QueryContext.Columns)
-	appendUserInteractionAbilityForIssue(&variables, d.QueryContext.Columns, d)
+	if until != nil {
+		variables["includeIssueUpdatedAt"] = githubv4.Boolean(true)
+	}
+	if err := appendUserInteractionAbilityForIssue(ctx, &variables, d.QueryContext.Columns, d); err != nil {
+		return nil, err
+	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_issue", &query.RateLimit))
+		logRateLimit(ctx, d, "github_issue", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_issue", "api_error", err)
 			return nil, err
 		}
 
 		for _, issue := range query.Repository.Issues.Nodes {
+			if until != nil && issue.UpdatedAt.After(*until) {
+				return nil, nil
+			}
 			d.StreamListItem(ctx, issue)
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
@@ -201,7 +268,10 @@ func tableGitHubRepositoryIssueGet(ctx context.Context, d *plugin.QueryData, _ *
 	fullName := quals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(fullName)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	var query struct {
 		RateLimit  models.RateLimit
@@ -217,8 +287,8 @@ func tableGitHubRepositoryIssueGet(ctx context.Context, d *plugin.QueryData, _ *
 	}
 	appendIssueColumnIncludes(&variables, d.QueryContext.Columns)
 
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_issue", &query.RateLimit))
+	err = client.Query(ctx, &query, variables)
+	logRateLimit(ctx, d, "github_issue", &query.RateLimit)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_issue", "api_error", err)
 		return nil, err
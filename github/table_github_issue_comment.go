@@ -84,10 +84,13 @@ func tableGitHubRepositoryIssueCommentList(ctx context.Context, d *plugin.QueryD
 	}
 	appendIssuePRCommentColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_issue_comment", &query.RateLimit))
+		logRateLimit(ctx, d, "github_issue_comment", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_issue_comment", "api_error", err)
 			return nil, err
@@ -45,7 +45,10 @@ func tableGitHubLicense() *plugin.Table {
 }
 
 func tableGitHubLicenseList(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	var query struct {
 		RateLimit models.RateLimit
@@ -54,8 +57,8 @@ func tableGitHubLicenseList(ctx context.Context, d *plugin.QueryData, _ *plugin.
 	variables := map[string]interface{}{}
 	appendLicenseColumnIncludes(&variables, d.QueryContext.Columns)
 
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_license", &query.RateLimit))
+	err = client.Query(ctx, &query, variables)
+	logRateLimit(ctx, d, "github_license", &query.RateLimit)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_license", "api_error", err)
 		return nil, err
@@ -83,7 +86,10 @@ func tableGitHubLicenseGetData(ctx context.Context, d *plugin.QueryData, _ *plug
 		"key": githubv4.String(key),
 	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	var query struct {
 		RateLimit models.RateLimit
@@ -91,8 +97,8 @@ func tableGitHubLicenseGetData(ctx context.Context, d *plugin.QueryData, _ *plug
 	}
 	appendLicenseColumnIncludes(&variables, d.QueryContext.Columns)
 
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_license", &query.RateLimit))
+	err = client.Query(ctx, &query, variables)
+	logRateLimit(ctx, d, "github_license", &query.RateLimit)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_license", "api_error", err)
 		return nil, err
@@ -19,7 +19,10 @@ func tableGitHubMyGist() *plugin.Table {
 }
 
 func tableGitHubMyGistList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	opt := &github.GistListOptions{ListOptions: github.ListOptions{PerPage: 100}}
 
@@ -87,13 +87,18 @@ func tableGitHubMyIssueList(ctx context.Context, d *plugin.QueryData, h *plugin.
 		"filters":  filters,
 	}
 	appendIssueColumnIncludes(&variables, d.QueryContext.Columns)
-	appendUserInteractionAbilityForIssue(&variables, d.QueryContext.Columns, d)
+	if err := appendUserInteractionAbilityForIssue(ctx, &variables, d.QueryContext.Columns, d); err != nil {
+		return nil, err
+	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_my_issue", &query.RateLimit))
+		logRateLimit(ctx, d, "github_my_issue", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_my_issue", "api_error", err)
 			return nil, err
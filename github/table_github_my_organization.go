@@ -21,7 +21,10 @@ func tableGitHubMyOrganization() *plugin.Table {
 }
 
 func tableGitHubMyOrganizationList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	pageSize := adjustPageSize(100, d.QueryContext.Limit)
 
@@ -45,7 +48,7 @@ func tableGitHubMyOrganizationList(ctx context.Context, d *plugin.QueryData, h *
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_my_organization", &query.RateLimit))
+		logRateLimit(ctx, d, "github_my_organization", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_my_organization", "api_error", err)
 			return nil, err
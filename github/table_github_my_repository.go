@@ -20,7 +20,10 @@ func tableGitHubMyRepository() *plugin.Table {
 }
 
 func tableGitHubMyRepositoryList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	pageSize := adjustPageSize(50, d.QueryContext.Limit)
 
@@ -43,7 +46,7 @@ func tableGitHubMyRepositoryList(ctx context.Context, d *plugin.QueryData, h *pl
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_my_repository", &query.RateLimit))
+		logRateLimit(ctx, d, "github_my_repository", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_my_repository", "api_error", err)
 			return nil, err
@@ -51,11 +51,14 @@ func tableGitHubMyStarredRepositoryList(ctx context.Context, d *plugin.QueryData
 	}
 	appendStarColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_my_star", &query.RateLimit))
+		logRateLimit(ctx, d, "github_my_star", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_my_star", "api_error", err)
 			return nil, err
@@ -52,12 +52,15 @@ func tableGitHubMyTeamList(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 	}
 	appendTeamColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	var teams []models.TeamWithCounts
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_my_team", &query.RateLimit))
+		logRateLimit(ctx, d, "github_my_team", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_my_team", "api_error", err)
 			return nil, err
@@ -121,7 +124,7 @@ func getAdditionalTeams(ctx context.Context, d *plugin.QueryData, client *github
 	var ts []models.TeamWithCounts
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_my_team", &query.RateLimit))
+		logRateLimit(ctx, d, "github_my_team", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_my_team", "api_error", err)
 			return nil, err
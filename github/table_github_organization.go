@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v55/github"
-	"github.com/shurcooL/githubv4"
 	"github.com/turbot/steampipe-plugin-github/github/models"
 
 	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
@@ -115,24 +114,13 @@ func tableGitHubOrganization() *plugin.Table {
 }
 
 func tableGitHubOrganizationList(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	client := connectV4(ctx, d)
-
 	login := d.EqualsQuals["login"].GetStringValue()
 
 	plugin.Logger(ctx).Debug("github_organization", login)
-	var query struct {
-		RateLimit    models.RateLimit
-		Organization models.OrganizationWithCounts `graphql:"organization(login: $login)"`
-	}
-
-	variables := map[string]interface{}{
-		"login": githubv4.String(login),
-	}
-
+	variables := map[string]interface{}{}
 	appendOrganizationColumnIncludes(&variables, d.QueryContext.Columns)
 
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_organization", &query.RateLimit))
+	org, err := organizationLookup.load(ctx, d, variables, login)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_organization", "api_error", err)
 		if strings.Contains(err.Error(), "Could not resolve to an Organization with the login of") {
@@ -140,8 +128,9 @@ func tableGitHubOrganizationList(ctx context.Context, d *plugin.QueryData, _ *pl
 		}
 		return nil, err
 	}
-
-	d.StreamListItem(ctx, query.Organization)
+	if org != nil {
+		d.StreamListItem(ctx, org)
+	}
 
 	return nil, nil
 }
@@ -152,7 +141,10 @@ func hydrateOrganizationHooksFromV3(ctx context.Context, d *plugin.QueryData, h
 	var orgHooks []*github.Hook
 	opt := &github.ListOptions{PerPage: 100}
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	for {
 		hooks, resp, err := client.Organizations.ListHooks(ctx, login, opt)
@@ -175,7 +167,10 @@ func hydrateOrganizationDataFromV3(ctx context.Context, d *plugin.QueryData, h *
 	org := h.Item.(models.OrganizationWithCounts)
 	login := org.Login
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	organization, _, err := client.Organizations.Get(ctx, login)
 	if err != nil {
 		plugin.Logger(ctx).Error("getOrganizationDetailV3", err)
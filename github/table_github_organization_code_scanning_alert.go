@@ -0,0 +1,323 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func gitHubCodeScanningAlertColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{
+			Name:        "alert_number",
+			Type:        proto.ColumnType_INT,
+			Description: "The code scanning alert number.",
+			Transform:   transform.FromField("Number"),
+		},
+		{
+			Name:        "state",
+			Type:        proto.ColumnType_STRING,
+			Description: "The state of the code scanning alert, one of open, dismissed or fixed.",
+		},
+		{
+			Name:        "rule_id",
+			Type:        proto.ColumnType_STRING,
+			Description: "The unique identifier of the rule used to detect the alert.",
+			Transform:   transform.FromField("Rule.ID"),
+		},
+		{
+			Name:        "rule_name",
+			Type:        proto.ColumnType_STRING,
+			Description: "The name of the rule used to detect the alert.",
+			Transform:   transform.FromField("Rule.Name"),
+		},
+		{
+			Name:        "rule_description",
+			Type:        proto.ColumnType_STRING,
+			Description: "A short description of the rule used to detect the alert.",
+			Transform:   transform.FromField("Rule.Description"),
+		},
+		{
+			Name:        "rule_tags",
+			Type:        proto.ColumnType_JSON,
+			Description: "The tags of the rule used to detect the alert.",
+			Transform:   transform.FromField("Rule.Tags"),
+		},
+		{
+			Name:        "severity",
+			Type:        proto.ColumnType_STRING,
+			Description: "The severity of the rule, one of none, note, warning or error.",
+			Transform:   transform.FromField("Rule.Severity"),
+		},
+		{
+			Name:        "security_severity_level",
+			Type:        proto.ColumnType_STRING,
+			Description: "The security severity of the rule, one of low, medium, high or critical.",
+			Transform:   transform.FromField("Rule.SecuritySeverityLevel"),
+		},
+		{
+			Name:        "tool_name",
+			Type:        proto.ColumnType_STRING,
+			Description: "The name of the tool that detected the alert, e.g. CodeQL.",
+			Transform:   transform.FromField("Tool.Name"),
+		},
+		{
+			Name:        "tool_guid",
+			Type:        proto.ColumnType_STRING,
+			Description: "The GUID of the tool that detected the alert.",
+			Transform:   transform.FromField("Tool.GUID"),
+		},
+		{
+			Name:        "tool_version",
+			Type:        proto.ColumnType_STRING,
+			Description: "The version of the tool that detected the alert.",
+			Transform:   transform.FromField("Tool.Version"),
+		},
+		{
+			Name:        "commit_sha",
+			Type:        proto.ColumnType_STRING,
+			Description: "The SHA of the commit of the most recent instance of the alert.",
+			Transform:   transform.FromField("MostRecentInstance.CommitSHA"),
+		},
+		{
+			Name:        "analysis_key",
+			Type:        proto.ColumnType_STRING,
+			Description: "The identifier of the configuration, e.g. the workflow and job, that analyzed the most recent instance of the alert.",
+			Transform:   transform.FromField("MostRecentInstance.AnalysisKey"),
+		},
+		{
+			Name:        "category",
+			Type:        proto.ColumnType_STRING,
+			Description: "The category of the analysis of the most recent instance of the alert.",
+			Transform:   transform.FromField("MostRecentInstance.Category"),
+		},
+		{
+			Name:        "message",
+			Type:        proto.ColumnType_STRING,
+			Description: "The message of the most recent instance of the alert.",
+			Transform:   transform.FromField("MostRecentInstance.Message.Text"),
+		},
+		{
+			Name:        "location_path",
+			Type:        proto.ColumnType_STRING,
+			Description: "The path of the file of the most recent instance of the alert.",
+			Transform:   transform.FromField("MostRecentInstance.Location.Path"),
+		},
+		{
+			Name:        "location_start_line",
+			Type:        proto.ColumnType_INT,
+			Description: "The line the most recent instance of the alert starts at.",
+			Transform:   transform.FromField("MostRecentInstance.Location.StartLine"),
+		},
+		{
+			Name:        "location_end_line",
+			Type:        proto.ColumnType_INT,
+			Description: "The line the most recent instance of the alert ends at.",
+			Transform:   transform.FromField("MostRecentInstance.Location.EndLine"),
+		},
+		{
+			Name:        "location_start_column",
+			Type:        proto.ColumnType_INT,
+			Description: "The column the most recent instance of the alert starts at.",
+			Transform:   transform.FromField("MostRecentInstance.Location.StartColumn"),
+		},
+		{
+			Name:        "location_end_column",
+			Type:        proto.ColumnType_INT,
+			Description: "The column the most recent instance of the alert ends at.",
+			Transform:   transform.FromField("MostRecentInstance.Location.EndColumn"),
+		},
+		{
+			Name:        "classifications",
+			Type:        proto.ColumnType_JSON,
+			Description: "The classifications of the file of the most recent instance of the alert, e.g. test or generated.",
+			Transform:   transform.FromField("MostRecentInstance.Classifications"),
+		},
+		{
+			Name:        "url",
+			Type:        proto.ColumnType_STRING,
+			Description: "The REST API URL of the alert resource.",
+		},
+		{
+			Name:        "html_url",
+			Type:        proto.ColumnType_STRING,
+			Description: "The GitHub URL of the alert resource.",
+		},
+		{
+			Name:        "instances_url",
+			Type:        proto.ColumnType_STRING,
+			Description: "The REST API URL for listing the instances of the alert.",
+		},
+		{
+			Name:        "created_at",
+			Type:        proto.ColumnType_TIMESTAMP,
+			Description: "The time that the alert was created.",
+			Transform:   transform.FromField("CreatedAt").NullIfZero().Transform(convertTimestamp),
+		},
+		{
+			Name:        "updated_at",
+			Type:        proto.ColumnType_TIMESTAMP,
+			Description: "The time that the alert was last updated.",
+			Transform:   transform.FromField("UpdatedAt").NullIfZero().Transform(convertTimestamp),
+		},
+		{
+			Name:        "fixed_at",
+			Type:        proto.ColumnType_TIMESTAMP,
+			Description: "The time that the alert was no longer detected and was considered fixed.",
+			Transform:   transform.FromField("FixedAt").NullIfZero().Transform(convertTimestamp),
+		},
+		{
+			Name:        "dismissed_at",
+			Type:        proto.ColumnType_TIMESTAMP,
+			Description: "The time that the alert was dismissed.",
+			Transform:   transform.FromField("DismissedAt").NullIfZero().Transform(convertTimestamp),
+		},
+		{
+			Name:        "dismissed_by_login",
+			Type:        proto.ColumnType_STRING,
+			Description: "The login of the user who dismissed the alert.",
+			Transform:   transform.FromField("DismissedBy.Login"),
+		},
+		{
+			Name:        "dismissed_reason",
+			Type:        proto.ColumnType_STRING,
+			Description: "The reason that the alert was dismissed, one of false positive, won't fix or used in tests.",
+		},
+		{
+			Name:        "dismissed_comment",
+			Type:        proto.ColumnType_STRING,
+			Description: "An optional comment associated with the alert's dismissal.",
+		},
+	}
+}
+
+// gitHubCodeScanningAlertKeyColumns are the optional qualifiers that are
+// passed to the API when listing code scanning alerts.
+func gitHubCodeScanningAlertKeyColumns() []*plugin.KeyColumn {
+	return []*plugin.KeyColumn{
+		{
+			Name:    "state",
+			Require: plugin.Optional,
+		},
+		{
+			Name:    "severity",
+			Require: plugin.Optional,
+		},
+		{
+			Name:    "security_severity_level",
+			Require: plugin.Optional,
+		},
+		{
+			Name:    "tool_name",
+			Require: plugin.Optional,
+		},
+	}
+}
+
+func tableGitHubOrganizationCodeScanningAlert() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_organization_code_scanning_alert",
+		Description: "Code scanning alerts from the repositories of an organization.",
+		List: &plugin.ListConfig{
+			KeyColumns: append([]*plugin.KeyColumn{
+				{
+					Name:    "organization",
+					Require: plugin.Required,
+				},
+			}, gitHubCodeScanningAlertKeyColumns()...),
+			ShouldIgnoreError: isNotFoundError([]string{"404", "403"}),
+			Hydrate:           tableGitHubOrganizationCodeScanningAlertList,
+		},
+		Columns: commonColumns(append(
+			gitHubCodeScanningAlertColumns(),
+			[]*plugin.Column{
+				{
+					Name:        "organization",
+					Type:        proto.ColumnType_STRING,
+					Description: "The login name of the organization.",
+					Transform:   transform.FromQual("organization"),
+				},
+				{
+					Name:        "repository_full_name",
+					Type:        proto.ColumnType_STRING,
+					Description: "The full name of the repository of the alert (login/repo-name).",
+					Transform:   transform.FromField("Repository.FullName"),
+				},
+				{
+					Name:        "ref",
+					Type:        proto.ColumnType_STRING,
+					Description: "The Git reference of the most recent instance of the alert, e.g. refs/heads/main.",
+					Transform:   transform.FromField("MostRecentInstance.Ref"),
+				},
+			}...,
+		)),
+	}
+}
+
+func tableGitHubOrganizationCodeScanningAlertList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+
+	opt := codeScanningAlertListOptions(d)
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		alerts, resp, err := client.CodeScanning.ListAlertsForOrg(ctx, org, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range alerts {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.ListOptions.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+// codeScanningAlertListOptions returns the options for listing code scanning
+// alerts, with the qualifiers the API can filter on. The API has a single
+// severity filter, which takes either a rule severity or a security severity
+// level, so if both are set only the latter is passed, and the rule severity
+// is filtered after listing. Alerts without a rule severity, 'none', can't be
+// filtered on.
+func codeScanningAlertListOptions(d *plugin.QueryData) *github.AlertListOptions {
+	quals := d.EqualsQuals
+	opt := &github.AlertListOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	if quals["state"] != nil {
+		opt.State = quals["state"].GetStringValue()
+	}
+	if quals["severity"] != nil && quals["severity"].GetStringValue() != "none" {
+		opt.Severity = quals["severity"].GetStringValue()
+	}
+	if quals["security_severity_level"] != nil {
+		opt.Severity = quals["security_severity_level"].GetStringValue()
+	}
+	if quals["tool_name"] != nil {
+		opt.ToolName = quals["tool_name"].GetStringValue()
+	}
+
+	opt.ListOptions.PerPage = adjustPageSize(opt.ListOptions.PerPage, d.QueryContext.Limit)
+
+	return opt
+}
@@ -60,7 +60,10 @@ func tableGitHubOrganizationCollaborator() *plugin.Table {
 }
 
 func listGitHubOrganizationCollaborators(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	quals := d.EqualsQuals
 	org := quals["organization"].GetStringValue()
@@ -114,7 +117,7 @@ func listGitHubOrganizationCollaborators(ctx context.Context, d *plugin.QueryDat
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_organization_collaborator", &query.RateLimit))
+		logRateLimit(ctx, d, "github_organization_collaborator", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_organization_collaborator", "api_error", err)
 			if strings.Contains(err.Error(), "Could not resolve to an Organization with the login of") {
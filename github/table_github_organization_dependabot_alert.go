@@ -237,7 +237,10 @@ func tableGitHubOrganizationDependabotAlertList(ctx context.Context, d *plugin.Q
 		opt.Scope = &scope
 	}
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	limit := d.QueryContext.Limit
 	if limit != nil {
 		if *limit < int64(opt.First) {
@@ -44,7 +44,10 @@ func tableGitHubOrganizationMember() *plugin.Table {
 }
 
 func tableGitHubOrganizationMemberList(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	quals := d.EqualsQuals
 	org := quals["organization"].GetStringValue()
@@ -74,7 +77,7 @@ func tableGitHubOrganizationMemberList(ctx context.Context, d *plugin.QueryData,
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_organization_member", &query.RateLimit))
+		logRateLimit(ctx, d, "github_organization_member", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_organization_member", "api_error", err)
 			if strings.Contains(err.Error(), "Could not resolve to an Organization with the login of") {
@@ -88,11 +88,14 @@ func tableGitHubOrganizationRulesetList(ctx context.Context, d *plugin.QueryData
 		"bypassActorCursor":   (*githubv4.String)(nil),
 	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_organization_ruleset", &query.RateLimit))
+		logRateLimit(ctx, d, "github_organization_ruleset", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_organization_ruleset", "api_error", err)
 			return nil, err
@@ -104,7 +107,7 @@ func tableGitHubOrganizationRulesetList(ctx context.Context, d *plugin.QueryData
 				rules = append(rules, rule.Node)
 			}
 			if edge.Node.Rules.PageInfo.HasNextPage {
-				additionalRules := getAdditionalOrgRules(ctx, client, org, edge.Node.DatabaseID, "")
+				additionalRules := getAdditionalOrgRules(ctx, d, client, org, edge.Node.DatabaseID, "")
 				rules = append(rules, additionalRules...)
 			}
 
@@ -113,7 +116,7 @@ func tableGitHubOrganizationRulesetList(ctx context.Context, d *plugin.QueryData
 				bypassActors = append(bypassActors, actor.Node)
 			}
 			if edge.Node.BypassActors.PageInfo.HasNextPage {
-				additionalBypassActors := getAdditionalOrgBypassActors(ctx, client, org, edge.Node.DatabaseID, "")
+				additionalBypassActors := getAdditionalOrgBypassActors(ctx, d, client, org, edge.Node.DatabaseID, "")
 				bypassActors = append(bypassActors, additionalBypassActors...)
 			}
 
@@ -144,7 +147,7 @@ func tableGitHubOrganizationRulesetList(ctx context.Context, d *plugin.QueryData
 	return nil, nil
 }
 
-func getAdditionalOrgRules(ctx context.Context, client *githubv4.Client, org string, databaseID int, initialCursor githubv4.String) []models.Rule {
+func getAdditionalOrgRules(ctx context.Context, d *plugin.QueryData, client *githubv4.Client, org string, databaseID int, initialCursor githubv4.String) []models.Rule {
 	var query struct {
 		RateLimit    models.RateLimit
 		Organization struct {
@@ -172,7 +175,7 @@ func getAdditionalOrgRules(ctx context.Context, client *githubv4.Client, org str
 	var rules []models.Rule
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_organization_ruleset.getAdditionalOrgRules", &query.RateLimit))
+		logRateLimit(ctx, d, "github_organization_ruleset.getAdditionalOrgRules", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_organization_ruleset.getAdditionalOrgRules", "api_error", err)
 			return nil
@@ -191,7 +194,7 @@ func getAdditionalOrgRules(ctx context.Context, client *githubv4.Client, org str
 	return rules
 }
 
-func getAdditionalOrgBypassActors(ctx context.Context, client *githubv4.Client, org string, databaseID int, initialCursor githubv4.String) []models.BypassActor {
+func getAdditionalOrgBypassActors(ctx context.Context, d *plugin.QueryData, client *githubv4.Client, org string, databaseID int, initialCursor githubv4.String) []models.BypassActor {
 	var query struct {
 		RateLimit    models.RateLimit
 		Organization struct {
@@ -219,7 +222,7 @@ func getAdditionalOrgBypassActors(ctx context.Context, client *githubv4.Client,
 	var bypassActors []models.BypassActor
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_organization_ruleset.getAdditionalOrgBypassActors", &query.RateLimit))
+		logRateLimit(ctx, d, "github_organization_ruleset.getAdditionalOrgBypassActors", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_organization_ruleset.getAdditionalOrgBypassActors", "api_error", err)
 			return nil
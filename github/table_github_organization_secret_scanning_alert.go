@@ -0,0 +1,245 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+// secretScanningAlert adds the fields of a secret scanning alert that the
+// client library doesn't decode.
+type secretScanningAlert struct {
+	github.SecretScanningAlert
+	UpdatedAt                *github.Timestamp `json:"updated_at,omitempty"`
+	ResolutionComment        *string           `json:"resolution_comment,omitempty"`
+	Validity                 *string           `json:"validity,omitempty"`
+	PushProtectionBypassed   *bool             `json:"push_protection_bypassed,omitempty"`
+	PushProtectionBypassedBy *github.User      `json:"push_protection_bypassed_by,omitempty"`
+	PushProtectionBypassedAt *github.Timestamp `json:"push_protection_bypassed_at,omitempty"`
+}
+
+func gitHubSecretScanningAlertColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{
+			Name:        "alert_number",
+			Type:        proto.ColumnType_INT,
+			Description: "The secret scanning alert number.",
+			Transform:   transform.FromField("Number"),
+		},
+		{
+			Name:        "state",
+			Type:        proto.ColumnType_STRING,
+			Description: "The state of the secret scanning alert, either open or resolved.",
+		},
+		{
+			Name:        "secret_type",
+			Type:        proto.ColumnType_STRING,
+			Description: "The type of the secret that was detected, e.g. github_personal_access_token.",
+		},
+		{
+			Name:        "secret_type_display_name",
+			Type:        proto.ColumnType_STRING,
+			Description: "The display name of the type of the secret that was detected.",
+		},
+		{
+			Name:        "validity",
+			Type:        proto.ColumnType_STRING,
+			Description: "Whether the secret is still valid, one of active, inactive or unknown.",
+		},
+		{
+			Name:        "resolution",
+			Type:        proto.ColumnType_STRING,
+			Description: "The reason the alert was resolved, one of false_positive, wont_fix, revoked, pattern_edited, pattern_deleted or used_in_tests.",
+		},
+		{
+			Name:        "resolution_comment",
+			Type:        proto.ColumnType_STRING,
+			Description: "An optional comment associated with the alert's resolution.",
+		},
+		{
+			Name:        "resolved_at",
+			Type:        proto.ColumnType_TIMESTAMP,
+			Description: "The time that the alert was resolved.",
+			Transform:   transform.FromField("ResolvedAt").NullIfZero().Transform(convertTimestamp),
+		},
+		{
+			Name:        "resolved_by_login",
+			Type:        proto.ColumnType_STRING,
+			Description: "The login of the user who resolved the alert.",
+			Transform:   transform.FromField("ResolvedBy.Login"),
+		},
+		{
+			Name:        "push_protection_bypassed",
+			Type:        proto.ColumnType_BOOL,
+			Description: "If true, the secret was pushed by bypassing push protection.",
+		},
+		{
+			Name:        "push_protection_bypassed_by_login",
+			Type:        proto.ColumnType_STRING,
+			Description: "The login of the user who bypassed push protection to push the secret.",
+			Transform:   transform.FromField("PushProtectionBypassedBy.Login"),
+		},
+		{
+			Name:        "push_protection_bypassed_at",
+			Type:        proto.ColumnType_TIMESTAMP,
+			Description: "The time that push protection was bypassed to push the secret.",
+			Transform:   transform.FromField("PushProtectionBypassedAt").NullIfZero().Transform(convertTimestamp),
+		},
+		{
+			Name:        "url",
+			Type:        proto.ColumnType_STRING,
+			Description: "The REST API URL of the alert resource.",
+			Transform:   transform.FromField("URL"),
+		},
+		{
+			Name:        "html_url",
+			Type:        proto.ColumnType_STRING,
+			Description: "The GitHub URL of the alert resource.",
+			Transform:   transform.FromField("HTMLURL"),
+		},
+		{
+			Name:        "locations_url",
+			Type:        proto.ColumnType_STRING,
+			Description: "The REST API URL for listing the locations of the secret.",
+			Transform:   transform.FromField("LocationsURL"),
+		},
+		{
+			Name:        "created_at",
+			Type:        proto.ColumnType_TIMESTAMP,
+			Description: "The time that the alert was created.",
+			Transform:   transform.FromField("CreatedAt").NullIfZero().Transform(convertTimestamp),
+		},
+		{
+			Name:        "updated_at",
+			Type:        proto.ColumnType_TIMESTAMP,
+			Description: "The time that the alert was last updated.",
+			Transform:   transform.FromField("UpdatedAt").NullIfZero().Transform(convertTimestamp),
+		},
+	}
+}
+
+// gitHubSecretScanningAlertKeyColumns are the optional qualifiers that are
+// passed to the API when listing secret scanning alerts.
+func gitHubSecretScanningAlertKeyColumns() []*plugin.KeyColumn {
+	return []*plugin.KeyColumn{
+		{
+			Name:    "state",
+			Require: plugin.Optional,
+		},
+		{
+			Name:    "secret_type",
+			Require: plugin.Optional,
+		},
+		{
+			Name:    "resolution",
+			Require: plugin.Optional,
+		},
+	}
+}
+
+func tableGitHubOrganizationSecretScanningAlert() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_organization_secret_scanning_alert",
+		Description: "Secret scanning alerts from the repositories of an organization.",
+		List: &plugin.ListConfig{
+			KeyColumns: append([]*plugin.KeyColumn{
+				{
+					Name:    "organization",
+					Require: plugin.Required,
+				},
+			}, gitHubSecretScanningAlertKeyColumns()...),
+			ShouldIgnoreError: isNotFoundError([]string{"404", "403"}),
+			Hydrate:           tableGitHubOrganizationSecretScanningAlertList,
+		},
+		Columns: commonColumns(append(
+			gitHubSecretScanningAlertColumns(),
+			[]*plugin.Column{
+				{
+					Name:        "organization",
+					Type:        proto.ColumnType_STRING,
+					Description: "The login name of the organization.",
+					Transform:   transform.FromQual("organization"),
+				},
+				{
+					Name:        "repository_full_name",
+					Type:        proto.ColumnType_STRING,
+					Description: "The full name of the repository of the alert (login/repo-name).",
+					Transform:   transform.FromField("Repository.FullName"),
+				},
+			}...,
+		)),
+	}
+}
+
+func tableGitHubOrganizationSecretScanningAlertList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, listSecretScanningAlerts(ctx, d, client, fmt.Sprintf("orgs/%s/secret-scanning/alerts", org), secretScanningAlertListParams(d))
+}
+
+// secretScanningAlertListParams returns the query parameters for listing
+// secret scanning alerts, with the qualifiers the API can filter on. The
+// alerts are requested without the secret itself, so that it is neither
+// returned nor stored in the HTTP cache.
+func secretScanningAlertListParams(d *plugin.QueryData) url.Values {
+	quals := d.EqualsQuals
+	params := url.Values{}
+	params.Set("hide_secret", "true")
+	params.Set("per_page", strconv.Itoa(adjustPageSize(100, d.QueryContext.Limit)))
+
+	if quals["state"] != nil {
+		params.Set("state", quals["state"].GetStringValue())
+	}
+	if quals["secret_type"] != nil {
+		params.Set("secret_type", quals["secret_type"].GetStringValue())
+	}
+	if quals["resolution"] != nil {
+		params.Set("resolution", quals["resolution"].GetStringValue())
+	}
+
+	return params
+}
+
+// listSecretScanningAlerts streams the secret scanning alerts listed at the
+// path with the query parameters.
+func listSecretScanningAlerts(ctx context.Context, d *plugin.QueryData, client *github.Client, path string, params url.Values) error {
+	for {
+		req, err := client.NewRequest("GET", path+"?"+params.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		var alerts []*secretScanningAlert
+		resp, err := client.Do(ctx, req, &alerts)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range alerts {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		params.Set("page", strconv.Itoa(resp.NextPage))
+	}
+
+	return nil
+}
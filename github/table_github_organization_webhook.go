@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func gitHubWebhookColumns() []*plugin.Column {
+	return []*plugin.Column{
+		// Top columns
+		{Name: "id", Type: proto.ColumnType_INT, Description: "Unique ID of the webhook."},
+		{Name: "name", Type: proto.ColumnType_STRING, Description: "The name of the webhook, which is always web."},
+		{Name: "active", Type: proto.ColumnType_BOOL, Description: "If true, events are delivered to the webhook."},
+		{Name: "events", Type: proto.ColumnType_JSON, Description: "The events the webhook is triggered for."},
+		{Name: "url_host", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.url").Transform(webhookURLHost), Description: "The host events are delivered to. The rest of the URL is left out, as it may hold credentials."},
+
+		// Other columns
+		{Name: "content_type", Type: proto.ColumnType_STRING, Transform: transform.FromField("Config.content_type"), Description: "The media type events are delivered as, either json or form."},
+		{Name: "insecure_ssl", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Config.insecure_ssl").Transform(transform.ToBool), Description: "If true, the SSL certificate of the host is not verified when delivering events."},
+		{Name: "has_secret", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Config.secret").Transform(webhookHasSecret), Description: "If true, deliveries are signed with a secret."},
+		{Name: "last_response_code", Type: proto.ColumnType_INT, Transform: transform.FromField("LastResponse.code"), Description: "The HTTP status code of the last delivery."},
+		{Name: "last_response_status", Type: proto.ColumnType_STRING, Transform: transform.FromField("LastResponse.status"), Description: "The status of the last delivery, e.g. active or unused."},
+		{Name: "last_response_message", Type: proto.ColumnType_STRING, Transform: transform.FromField("LastResponse.message"), Description: "The message of the last delivery, e.g. the error it failed with."},
+		{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the webhook was created."},
+		{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the webhook was last updated."},
+		{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "The REST API URL of the webhook."},
+		{Name: "ping_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("PingURL"), Description: "The REST API URL for pinging the webhook."},
+	}
+}
+
+func tableGitHubOrganizationWebhook() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_organization_webhook",
+		Description: "Webhooks that deliver the events of an organization to an external service.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.SingleColumn("organization"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubOrganizationWebhookList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"organization", "id"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubOrganizationWebhookGet,
+		},
+		Columns: commonColumns(append([]*plugin.Column{
+			{Name: "organization", Type: proto.ColumnType_STRING, Transform: transform.FromQual("organization"), Description: "The login name of the organization."},
+		}, gitHubWebhookColumns()...)),
+	}
+}
+
+func tableGitHubOrganizationWebhookList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		hooks, resp, err := client.Organizations.ListHooks(ctx, org, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range hooks {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubOrganizationWebhookGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	org := d.EqualsQualString("organization")
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	hook, _, err := client.Organizations.GetHook(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return hook, nil
+}
+
+// webhookURLHost returns the host of the URL events are delivered to,
+// without the user info, path or query, which may hold credentials.
+func webhookURLHost(_ context.Context, input *transform.TransformData) (interface{}, error) {
+	raw, ok := input.Value.(string)
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, nil
+	}
+	return u.Host, nil
+}
+
+// webhookHasSecret returns whether the webhook has a secret, which the API
+// returns masked.
+func webhookHasSecret(_ context.Context, input *transform.TransformData) (interface{}, error) {
+	secret, _ := input.Value.(string)
+	return secret != "", nil
+}
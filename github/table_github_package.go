@@ -51,7 +51,10 @@ func tableGitHubPackage() *plugin.Table {
 }
 
 func tableGitHubPackageList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	org, packageType := d.EqualsQuals["organization"].GetStringValue(), d.EqualsQuals["package_type"].GetStringValue()
 	visibility := d.EqualsQuals["visibility"].GetStringValue()
 
@@ -109,7 +112,10 @@ func tableGitHubPackageList(ctx context.Context, d *plugin.QueryData, h *plugin.
 }
 
 func tableGitHubPackageGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	org := d.EqualsQuals["organization"].GetStringValue()
 	name := d.EqualsQuals["name"].GetStringValue()
 	packageType := d.EqualsQuals["package_type"].GetStringValue()
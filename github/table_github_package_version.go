@@ -63,7 +63,10 @@ type PackageVersionInfo struct {
 }
 
 func tableGitHubPackageVersionList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	pkg := h.Item.(*github.Package)
 	org, packageType, packageName := d.EqualsQuals["organization"].GetStringValue(), d.EqualsQuals["package_type"].GetStringValue(), d.EqualsQuals["package_name"].GetStringValue()
 	visibility := d.EqualsQuals["visibility"].GetStringValue()
@@ -137,7 +140,10 @@ func tableGitHubPackageVersionList(ctx context.Context, d *plugin.QueryData, h *
 //// HYDRATE FUNCTION
 
 func tableGitHubPackageVersionGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	org := d.EqualsQuals["organization"].GetStringValue()
 	name := d.EqualsQuals["package_name"].GetStringValue()
 	packageType := d.EqualsQuals["package_type"].GetStringValue()
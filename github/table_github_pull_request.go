@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/shurcooL/githubv4"
 	"github.com/turbot/steampipe-plugin-github/github/models"
@@ -88,7 +89,19 @@ func gitHubPullRequestColumns() []*plugin.Column {
 		{Name: "reviews_total_count", Type: proto.ColumnType_INT, Hydrate: prHydrateReviewCount, Transform: transform.FromValue(), Description: "A count of completed reviews on the pull request."},
 	}
 
-	return append(sharedPullRequestColumns(), tableCols...)
+	columns := append(sharedPullRequestColumns(), tableCols...)
+
+	// Pull requests can be listed in the order they were last updated in.
+	// The column is copied, as the shared columns are used by other tables
+	for i, c := range columns {
+		if c.Name == "updated_at" {
+			sorted := *c
+			sorted.Sort = plugin.SortAll
+			columns[i] = &sorted
+		}
+	}
+
+	return columns
 }
 
 func tableGitHubPullRequest() *plugin.Table {
@@ -99,6 +112,10 @@ func tableGitHubPullRequest() *plugin.Table {
 			KeyColumns: []*plugin.KeyColumn{
 				{Name: "repository_full_name", Require: plugin.Required},
 				{Name: "state", Require: plugin.Optional},
+				{Name: "base_ref_name", Require: plugin.Optional},
+				{Name: "head_ref_name", Require: plugin.Optional},
+				{Name: "labels", Require: plugin.Optional, Operators: []string{"?", "@>"}},
+				{Name: "updated_at", Require: plugin.Optional, Operators: []string{">", ">=", "=", "<=", "<"}},
 			},
 			ShouldIgnoreError: isNotFoundError([]string{"404"}),
 			Hydrate:           tableGitHubPullRequestList,
@@ -135,6 +152,72 @@ func tableGitHubPullRequestList(ctx context.Context, d *plugin.QueryData, h *plu
 		}
 	}
 
+	variables := map[string]interface{}{
+		"owner":       githubv4.String(owner),
+		"name":        githubv4.String(repo),
+		"pageSize":    githubv4.Int(pageSize),
+		"cursor":      (*githubv4.String)(nil),
+		"states":      states,
+		"baseRefName": (*githubv4.String)(nil),
+		"headRefName": (*githubv4.String)(nil),
+		"labels":      (*[]githubv4.String)(nil),
+	}
+
+	if quals["base_ref_name"] != nil {
+		variables["baseRefName"] = githubv4.String(quals["base_ref_name"].GetStringValue())
+	}
+
+	if quals["head_ref_name"] != nil {
+		variables["headRefName"] = githubv4.String(quals["head_ref_name"].GetStringValue())
+	}
+
+	if d.Quals["labels"] != nil {
+		if label := labelFilter(d.Quals["labels"].Quals); label != "" {
+			variables["labels"] = []githubv4.String{githubv4.String(label)}
+		}
+	}
+
+	// GitHub can't filter pull requests on the time they were last updated,
+	// so they're listed in the order they were updated in instead, which
+	// allows paging to stop at the first one outside of the time range.
+	// Strict and inclusive bounds are passed alike, as the times in quals may
+	// have fractions of a second that GitHub's don't, and Postgres drops the
+	// pull requests on a strict bound.
+	var since, until *time.Time
+	if d.Quals["updated_at"] != nil {
+		for _, q := range d.Quals["updated_at"].Quals {
+			givenTime := q.Value.GetTimestampValue().AsTime()
+
+			switch q.Operator {
+			case ">", ">=":
+				since = &givenTime
+			case "=":
+				since = &givenTime
+				until = &givenTime
+			case "<", "<=":
+				until = &givenTime
+			}
+		}
+	}
+
+	order := githubv4.IssueOrder{Field: githubv4.IssueOrderFieldCreatedAt, Direction: githubv4.OrderDirectionAsc}
+	if since != nil || until != nil {
+		order = githubv4.IssueOrder{Field: githubv4.IssueOrderFieldUpdatedAt, Direction: githubv4.OrderDirectionDesc}
+	}
+	// A sort order is only pushed down for the updated_at column
+	for _, sortColumn := range d.QueryContext.SortOrder {
+		if sortColumn.Column != "updated_at" {
+			continue
+		}
+		order.Field = githubv4.IssueOrderFieldUpdatedAt
+		if sortColumn.Order == plugin.SortAsc {
+			order.Direction = githubv4.OrderDirectionAsc
+		} else {
+			order.Direction = githubv4.OrderDirectionDesc
+		}
+	}
+	variables["orderBy"] = order
+
 	var query struct {
 		RateLimit  models.RateLimit
 		Repository struct {
@@ -142,31 +225,49 @@ func tableGitHubPullRequestList(ctx context.Context, d *plugin.QueryData, h *plu
 				PageInfo   models.PageInfo
 				TotalCount int
 				Nodes      []models.PullRequest
-			} `graphql:"pullRequests(first: $pageSize, after: $cursor, states: $states)"`
+			} `graphql:"pullRequests(first: $pageSize, after: $cursor, states: $states, baseRefName: $baseRefName, headRefName: $headRefName, labels: $labels, orderBy: $orderBy)"`
 		} `graphql:"repository(owner: $owner, name: $name)"`
 	}
 
-	variables := map[string]interface{}{
-		"owner":    githubv4.String(owner),
-		"name":     githubv4.String(repo),
-		"pageSize": githubv4.Int(pageSize),
-		"cursor":   (*githubv4.String)(nil),
-		"states":   states,
-	}
 	appendPullRequestColumnIncludes(&variables, d.QueryContext.Columns)
-	appendUserInteractionAbilityForIssue(&variables, d.QueryContext.Columns, d)
+	if since != nil || until != nil {
+		variables["includePRUpdatedAt"] = githubv4.Boolean(true)
+	}
+	if err := appendUserInteractionAbilityForIssue(ctx, &variables, d.QueryContext.Columns, d); err != nil {
+		return nil, err
+	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_pull_request", &query.RateLimit))
+		logRateLimit(ctx, d, "github_pull_request", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_pull_request", "api_error", err)
 			return nil, err
 		}
 
 		for _, issue := range query.Repository.PullRequests.Nodes {
+			// As they're listed in the order they were updated in, pull
+			// requests are skipped until the time range is reached, and end
+			// the listing once it's passed
+			if since != nil || until != nil {
+				before := since != nil && issue.UpdatedAt.Before(*since)
+				after := until != nil && issue.UpdatedAt.After(*until)
+				passed := after
+				if order.Direction == githubv4.OrderDirectionDesc {
+					passed = before
+				}
+				if passed {
+					return nil, nil
+				}
+				if before || after {
+					continue
+				}
+			}
 			d.StreamListItem(ctx, issue)
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
@@ -190,7 +291,10 @@ func tableGitHubPullRequestGet(ctx context.Context, d *plugin.QueryData, _ *plug
 	fullName := quals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(fullName)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	var query struct {
 		RateLimit  models.RateLimit
@@ -206,8 +310,8 @@ func tableGitHubPullRequestGet(ctx context.Context, d *plugin.QueryData, _ *plug
 	}
 	appendPullRequestColumnIncludes(&variables, d.QueryContext.Columns)
 
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_pull_request", &query.RateLimit))
+	err = client.Query(ctx, &query, variables)
+	logRateLimit(ctx, d, "github_pull_request", &query.RateLimit)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_pull_request", "api_error", err)
 		return nil, err
@@ -51,11 +51,14 @@ func tableGitHubRepositoryPullRequestCommentList(ctx context.Context, d *plugin.
 	}
 	appendIssuePRCommentColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_pull_request_comment", &query.RateLimit))
+		logRateLimit(ctx, d, "github_pull_request_comment", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_pull_request_comment", "api_error", err)
 			return nil, err
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubPullRequestDiffHunk() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_pull_request_diff_hunk",
+		Description: "The hunks of the diffs of the files changed by a GitHub pull request, with their added and removed lines.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "number"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubPullRequestDiffHunkList,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository that contains the pull request."},
+			{Name: "number", Type: proto.ColumnType_INT, Transform: transform.FromQual("number"), Description: "The number of the pull request."},
+			{Name: "filename", Type: proto.ColumnType_STRING, Description: "The path of the file the hunk changes."},
+			{Name: "hunk_number", Type: proto.ColumnType_INT, Description: "The number of the hunk in the diff of the file, starting at 1."},
+			{Name: "header", Type: proto.ColumnType_STRING, Description: "The header of the hunk, e.g. @@ -10,7 +10,8 @@ func main() {."},
+			{Name: "additions", Type: proto.ColumnType_INT, Description: "The number of lines the hunk adds."},
+			{Name: "deletions", Type: proto.ColumnType_INT, Description: "The number of lines the hunk removes."},
+
+			// Other columns
+			{Name: "section", Type: proto.ColumnType_STRING, Description: "The text after the range of the header, usually the function or section the hunk is in."},
+			{Name: "old_start", Type: proto.ColumnType_INT, Description: "The first line of the hunk in the file before the change."},
+			{Name: "old_lines", Type: proto.ColumnType_INT, Description: "The number of lines of the hunk in the file before the change."},
+			{Name: "new_start", Type: proto.ColumnType_INT, Description: "The first line of the hunk in the file after the change."},
+			{Name: "new_lines", Type: proto.ColumnType_INT, Description: "The number of lines of the hunk in the file after the change."},
+			{Name: "added_lines", Type: proto.ColumnType_JSON, Description: "The lines the hunk adds, each with its line_number in the file after the change and its content."},
+			{Name: "removed_lines", Type: proto.ColumnType_JSON, Description: "The lines the hunk removes, each with its line_number in the file before the change and its content."},
+			{Name: "file_status", Type: proto.ColumnType_STRING, Description: "The change made to the file, one of added, removed, modified, renamed, copied, changed or unchanged."},
+		}),
+	}
+}
+
+type diffHunk struct {
+	Filename     string
+	FileStatus   string
+	HunkNumber   int
+	Header       string
+	Section      string
+	OldStart     int
+	OldLines     int
+	NewStart     int
+	NewLines     int
+	Additions    int
+	Deletions    int
+	AddedLines   []diffLine
+	RemovedLines []diffLine
+}
+
+type diffLine struct {
+	LineNumber int    `json:"line_number"`
+	Content    string `json:"content"`
+}
+
+func tableGitHubPullRequestDiffHunkList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	err := listPullRequestFiles(ctx, d, func(file *github.CommitFile) bool {
+		for _, hunk := range parseDiffHunks(file.GetPatch()) {
+			hunk.Filename = file.GetFilename()
+			hunk.FileStatus = file.GetStatus()
+			d.StreamListItem(ctx, hunk)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return false
+			}
+		}
+		return true
+	})
+	return nil, err
+}
+
+// parseDiffHunks splits the unified diff of a file into its hunks, each of
+// which starts with a header giving the lines it covers, e.g.
+//
+//	@@ -10,7 +10,8 @@ func main() {
+//
+// where a count of 1 may be left out. Lines starting with a space are
+// context, with - removed and with + added.
+func parseDiffHunks(patch string) []*diffHunk {
+	var hunks []*diffHunk
+	var hunk *diffHunk
+	var oldLine, newLine int
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			hunk = parseDiffHunkHeader(line)
+			if hunk == nil {
+				continue
+			}
+			hunk.HunkNumber = len(hunks) + 1
+			hunks = append(hunks, hunk)
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			continue
+		}
+		if hunk == nil || line == "" {
+			continue
+		}
+
+		switch line[0] {
+		case '+':
+			hunk.AddedLines = append(hunk.AddedLines, diffLine{LineNumber: newLine, Content: line[1:]})
+			hunk.Additions++
+			newLine++
+		case '-':
+			hunk.RemovedLines = append(hunk.RemovedLines, diffLine{LineNumber: oldLine, Content: line[1:]})
+			hunk.Deletions++
+			oldLine++
+		case ' ':
+			oldLine++
+			newLine++
+		}
+		// Anything else, e.g. "\ No newline at end of file", isn't a line
+	}
+	return hunks
+}
+
+// parseDiffHunkHeader parses a hunk header, or returns nil if it isn't one.
+func parseDiffHunkHeader(header string) *diffHunk {
+	ranges, section, ok := strings.Cut(strings.TrimPrefix(header, "@@ "), " @@")
+	if !ok {
+		return nil
+	}
+	oldRange, newRange, ok := strings.Cut(ranges, " ")
+	if !ok || !strings.HasPrefix(oldRange, "-") || !strings.HasPrefix(newRange, "+") {
+		return nil
+	}
+	oldStart, oldLines, ok := parseDiffRange(oldRange[1:])
+	if !ok {
+		return nil
+	}
+	newStart, newLines, ok := parseDiffRange(newRange[1:])
+	if !ok {
+		return nil
+	}
+
+	return &diffHunk{
+		Header:   header,
+		Section:  strings.TrimPrefix(section, " "),
+		OldStart: oldStart,
+		OldLines: oldLines,
+		NewStart: newStart,
+		NewLines: newLines,
+	}
+}
+
+// parseDiffRange parses the start and count of a hunk range, e.g. 10,7.
+func parseDiffRange(r string) (int, int, bool) {
+	startText, countText, hasCount := strings.Cut(r, ",")
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return 0, 0, false
+	}
+	count := 1
+	if hasCount {
+		if count, err = strconv.Atoi(countText); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, count, true
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDiffHunks(t *testing.T) {
+	cases := []struct {
+		name  string
+		patch string
+		want  []*diffHunk
+	}{
+		{
+			name: "multiple hunks",
+			patch: "@@ -1,3 +1,3 @@ package main\n" +
+				" import \"fmt\"\n" +
+				"-var x = 1\n" +
+				"+var x = 2\n" +
+				" \n" +
+				"@@ -10,4 +10,5 @@ func main() {\n" +
+				" \tfmt.Println(x)\n" +
+				"+\tfmt.Println(y)\n" +
+				" }\n",
+			want: []*diffHunk{
+				{
+					HunkNumber:   1,
+					Header:       "@@ -1,3 +1,3 @@ package main",
+					Section:      "package main",
+					OldStart:     1,
+					OldLines:     3,
+					NewStart:     1,
+					NewLines:     3,
+					Additions:    1,
+					Deletions:    1,
+					AddedLines:   []diffLine{{LineNumber: 2, Content: "var x = 2"}},
+					RemovedLines: []diffLine{{LineNumber: 2, Content: "var x = 1"}},
+				},
+				{
+					HunkNumber: 2,
+					Header:     "@@ -10,4 +10,5 @@ func main() {",
+					Section:    "func main() {",
+					OldStart:   10,
+					OldLines:   4,
+					NewStart:   10,
+					NewLines:   5,
+					Additions:  1,
+					AddedLines: []diffLine{{LineNumber: 11, Content: "\tfmt.Println(y)"}},
+				},
+			},
+		},
+		{
+			name: "no newline at end of file",
+			patch: "@@ -1,2 +1,2 @@\n" +
+				" a\n" +
+				"-b\n" +
+				"\\ No newline at end of file\n" +
+				"+c\n" +
+				"\\ No newline at end of file",
+			want: []*diffHunk{
+				{
+					HunkNumber:   1,
+					Header:       "@@ -1,2 +1,2 @@",
+					OldStart:     1,
+					OldLines:     2,
+					NewStart:     1,
+					NewLines:     2,
+					Additions:    1,
+					Deletions:    1,
+					AddedLines:   []diffLine{{LineNumber: 2, Content: "c"}},
+					RemovedLines: []diffLine{{LineNumber: 2, Content: "b"}},
+				},
+			},
+		},
+		{
+			name:  "header without counts",
+			patch: "@@ -1 +1 @@\n-old\n+new",
+			want: []*diffHunk{
+				{
+					HunkNumber:   1,
+					Header:       "@@ -1 +1 @@",
+					OldStart:     1,
+					OldLines:     1,
+					NewStart:     1,
+					NewLines:     1,
+					Additions:    1,
+					Deletions:    1,
+					AddedLines:   []diffLine{{LineNumber: 1, Content: "new"}},
+					RemovedLines: []diffLine{{LineNumber: 1, Content: "old"}},
+				},
+			},
+		},
+		{
+			name:  "new file",
+			patch: "@@ -0,0 +1,2 @@\n+first\n+second",
+			want: []*diffHunk{
+				{
+					HunkNumber: 1,
+					Header:     "@@ -0,0 +1,2 @@",
+					OldStart:   0,
+					OldLines:   0,
+					NewStart:   1,
+					NewLines:   2,
+					Additions:  2,
+					AddedLines: []diffLine{{LineNumber: 1, Content: "first"}, {LineNumber: 2, Content: "second"}},
+				},
+			},
+		},
+		{
+			// Renamed files without changes and binary files have no patch
+			name:  "no patch",
+			patch: "",
+		},
+		{
+			name:  "malformed header",
+			patch: "@@ -a,1 +1,1 @@\n-old\n+new",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseDiffHunks(tc.patch)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got hunks:")
+				for _, h := range got {
+					t.Errorf("  %+v", *h)
+				}
+				t.Errorf("want hunks:")
+				for _, h := range tc.want {
+					t.Errorf("  %+v", *h)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubPullRequestFile() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_pull_request_file",
+		Description: "The files changed by a GitHub pull request, with their diff stats and patches.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "number"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubPullRequestFileList,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository that contains the pull request."},
+			{Name: "number", Type: proto.ColumnType_INT, Transform: transform.FromQual("number"), Description: "The number of the pull request."},
+			{Name: "filename", Type: proto.ColumnType_STRING, Description: "The path of the file."},
+			{Name: "status", Type: proto.ColumnType_STRING, Description: "The change made to the file, one of added, removed, modified, renamed, copied, changed or unchanged."},
+			{Name: "additions", Type: proto.ColumnType_INT, Description: "The number of lines added to the file."},
+			{Name: "deletions", Type: proto.ColumnType_INT, Description: "The number of lines removed from the file."},
+			{Name: "changes", Type: proto.ColumnType_INT, Description: "The number of lines changed in the file."},
+
+			// Other columns
+			{Name: "previous_filename", Type: proto.ColumnType_STRING, Description: "The path the file had before it was renamed."},
+			{Name: "sha", Type: proto.ColumnType_STRING, Transform: transform.FromField("SHA"), Description: "The SHA of the blob of the file."},
+			{Name: "blob_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("BlobURL"), Description: "The URL of the file on GitHub."},
+			{Name: "raw_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("RawURL"), Description: "The URL of the raw content of the file."},
+			{Name: "contents_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("ContentsURL"), Description: "The API URL of the contents of the file."},
+			{Name: "patch", Type: proto.ColumnType_STRING, Description: "The unified diff of the file, which is left out for binary files and very large diffs."},
+		}),
+	}
+}
+
+func tableGitHubPullRequestFileList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	err := listPullRequestFiles(ctx, d, func(file *github.CommitFile) bool {
+		d.StreamListItem(ctx, file)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	return nil, err
+}
+
+// listPullRequestFiles calls fn with each file changed by the pull request
+// in the quals, until fn returns false. GitHub lists at most 3000 files.
+func listPullRequestFiles(ctx context.Context, d *plugin.QueryData, fn func(*github.CommitFile) bool) error {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+	number := int(d.EqualsQuals["number"].GetInt64Value())
+	opts := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			if file != nil && !fn(file) {
+				return nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return nil
+}
@@ -28,7 +28,10 @@ func tableGitHubRateLimit() *plugin.Table {
 }
 
 func listGitHubRateLimit(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	rateLimits, _, err := client.RateLimits(ctx)
 	if err != nil {
@@ -36,8 +36,11 @@ func listGitHubRateLimitGraphQL(ctx context.Context, d *plugin.QueryData, _ *plu
 	variables := map[string]interface{}{}
 	appendRateLimitColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
-	err := client.Query(ctx, &query, variables)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	err = client.Query(ctx, &query, variables)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_rate_limit_graphql", "api_error", err)
 		if strings.Contains(err.Error(), "Could not resolve to an Organization with the login of") {
@@ -53,7 +53,10 @@ func tableGitHubRelease() *plugin.Table {
 }
 
 func tableGitHubReleaseList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	fullName := d.EqualsQuals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(fullName)
@@ -99,7 +102,10 @@ func tableGitHubReleaseGet(ctx context.Context, d *plugin.QueryData, h *plugin.H
 	owner, repo := parseRepoFullName(fullName)
 	plugin.Logger(ctx).Trace("tableGitHubReleaseGet", "owner", owner, "repo", repo, "id", id)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	release, _, err := client.Repositories.GetRelease(ctx, owner, repo, id)
 	if err != nil {
 		return nil, err
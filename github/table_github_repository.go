@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v55/github"
-	"github.com/shurcooL/githubv4"
 	"github.com/turbot/steampipe-plugin-github/github/models"
 	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
@@ -118,31 +117,24 @@ func tableGitHubRepository() *plugin.Table {
 }
 
 func tableGitHubRepositoryList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connectV4(ctx, d)
-
 	repoFullName := d.EqualsQuals["full_name"].GetStringValue()
-	owner, repoName := parseRepoFullName(repoFullName)
-
-	var query struct {
-		RateLimit  models.RateLimit
-		Repository models.Repository `graphql:"repository(owner: $owner, name: $name)"`
-	}
 
-	variables := map[string]interface{}{
-		"owner": githubv4.String(owner),
-		"name":  githubv4.String(repoName),
-	}
+	variables := map[string]interface{}{}
 	appendRepoColumnIncludes(&variables, d.QueryContext.Columns)
-	appendUserInteractionAbilityForIssue(&variables, d.QueryContext.Columns, d)
+	if err := appendUserInteractionAbilityForIssue(ctx, &variables, d.QueryContext.Columns, d); err != nil {
+		return nil, err
+	}
 
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_repository", &query.RateLimit))
+	// Lookups of the repositories of a `full_name in (...)` query are
+	// batched into a single request
+	repo, err := repositoryLookup.load(ctx, d, variables, repoFullName)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_repository", "api_error", err)
 		return nil, err
 	}
-
-	d.StreamListItem(ctx, query.Repository)
+	if repo != nil {
+		d.StreamListItem(ctx, repo)
+	}
 
 	return nil, nil
 }
@@ -174,7 +166,10 @@ func hydrateRepositoryDataFromV3(ctx context.Context, d *plugin.QueryData, h *pl
 	owner := repo.Owner.Login
 	repoName := repo.Name
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	r, _, err := client.Repositories.Get(ctx, owner, repoName)
 	if err != nil {
 		if strings.Contains(err.Error(), "404") {
@@ -197,7 +192,10 @@ func hydrateRepositoryHooksFromV3(ctx context.Context, d *plugin.QueryData, h *p
 	owner := repo.Owner.Login
 	repoName := repo.Name
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	var repositoryHooks []*github.Hook
 	opt := &github.ListOptions{PerPage: 100}
 
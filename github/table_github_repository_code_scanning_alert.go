@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubRepositoryCodeScanningAlert() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_repository_code_scanning_alert",
+		Description: "Code scanning alerts from a repository.",
+		List: &plugin.ListConfig{
+			KeyColumns: append([]*plugin.KeyColumn{
+				{
+					Name:    "repository_full_name",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "ref",
+					Require: plugin.Optional,
+				},
+			}, gitHubCodeScanningAlertKeyColumns()...),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubRepositoryCodeScanningAlertList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "alert_number"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubRepositoryCodeScanningAlertGet,
+		},
+		Columns: commonColumns(append(
+			gitHubCodeScanningAlertColumns(),
+			[]*plugin.Column{
+				{
+					Name:        "repository_full_name",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromQual("repository_full_name"),
+					Description: "The full name of the repository (login/repo-name).",
+				},
+				{
+					Name:        "ref",
+					Type:        proto.ColumnType_STRING,
+					Description: "The Git reference of the most recent instance of the alert, e.g. refs/heads/main.",
+					Transform:   transform.FromField("MostRecentInstance.Ref"),
+				},
+			}...,
+		)),
+	}
+}
+
+func tableGitHubRepositoryCodeScanningAlertList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	fullName := d.EqualsQualString("repository_full_name")
+	owner, repo := parseRepoFullName(fullName)
+
+	opt := codeScanningAlertListOptions(d)
+	if ref := d.EqualsQualString("ref"); ref != "" {
+		opt.Ref = ref
+	}
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		alerts, resp, err := client.CodeScanning.ListAlertsForRepo(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range alerts {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.ListOptions.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubRepositoryCodeScanningAlertGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	alertNumber := d.EqualsQuals["alert_number"].GetInt64Value()
+	fullName := d.EqualsQualString("repository_full_name")
+	owner, repo := parseRepoFullName(fullName)
+	plugin.Logger(ctx).Trace("tableGitHubRepositoryCodeScanningAlertGet", "owner", owner, "repo", repo, "alertNumber", alertNumber)
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	alert, _, err := client.CodeScanning.GetAlert(ctx, owner, repo, alertNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
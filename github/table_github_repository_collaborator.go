@@ -93,10 +93,13 @@ func tableGitHubRepositoryCollaboratorList(ctx context.Context, d *plugin.QueryD
 	}
 	appendRepoCollaboratorColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_collaborator", &query.RateLimit))
+		logRateLimit(ctx, d, "github_repository_collaborator", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_repository_collaborator", "api_error", err, "repository", fullName)
 			return nil, err
@@ -117,11 +117,14 @@ func tableGitHubRepositoryContentGet(ctx context.Context, d *plugin.QueryData, h
 		"expression": githubv4.String("HEAD:" + path),
 	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 		return nil, client.Query(ctx, &query, variables)
 	}
-	_, err := plugin.RetryHydrate(ctx, d, h, listPage, retryConfig())
+	_, err = plugin.RetryHydrate(ctx, d, h, listPage, retryConfig())
 	if err != nil {
 		plugin.Logger(ctx).Error("github_repository_content.tableGitHubRepositoryContentGet", "api_error", err, "repository", repo)
 		return nil, err
@@ -204,12 +207,15 @@ func getFileModeAndGeneratedDetails(ctx context.Context, d *plugin.QueryData, h
 		"expression": githubv4.String("HEAD:" + parentFilePath),
 	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 		return nil, client.Query(ctx, &query, variables)
 	}
 
-	_, err := plugin.RetryHydrate(ctx, d, h, listPage, retryConfig())
+	_, err = plugin.RetryHydrate(ctx, d, h, listPage, retryConfig())
 	if err != nil {
 		plugin.Logger(ctx).Error("github_repository_content.getFileModeAndGeneratedDetails", "api_error", err, "repository", repo)
 		return nil, err
@@ -265,12 +271,15 @@ func getFileContents(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 		"expression": githubv4.String("HEAD:" + filterPath),
 	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return err
+	}
 	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 		return nil, client.Query(ctx, &query, variables)
 	}
 
-	_, err := plugin.RetryHydrate(ctx, d, h, listPage, retryConfig())
+	_, err = plugin.RetryHydrate(ctx, d, h, listPage, retryConfig())
 	if err != nil {
 		plugin.Logger(ctx).Error("github_repository_content.getFileContents", "api_error", err, "repository", repo)
 		return err
@@ -91,7 +91,10 @@ func tableGitHubRepositoryDependabotAlertList(ctx context.Context, d *plugin.Que
 		opt.Scope = &scope
 	}
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	limit := d.QueryContext.Limit
 	if limit != nil {
 		if *limit < int64(opt.First) {
@@ -136,7 +139,10 @@ func tableGitHubRepositoryDependabotAlertGet(ctx context.Context, d *plugin.Quer
 	owner, repo = parseRepoFullName(fullName)
 	logger.Trace("tableGitHubDependabotAlertGet", "owner", owner, "repo", repo, "alertNumber", alertNumber)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	alert, _, err := client.Dependabot.GetRepoAlert(ctx, owner, repo, alertNumber)
 	if err != nil {
 		return nil, err
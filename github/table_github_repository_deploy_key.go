@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubRepositoryDeployKey() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_repository_deploy_key",
+		Description: "Deploy keys are SSH keys that grant access to a single repository.",
+		List: &plugin.ListConfig{
+			KeyColumns:        plugin.SingleColumn("repository_full_name"),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubRepositoryDeployKeyList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "id"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubRepositoryDeployKeyGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository the deploy key grants access to."},
+			{Name: "id", Type: proto.ColumnType_INT, Description: "Unique ID of the deploy key."},
+			{Name: "title", Type: proto.ColumnType_STRING, Description: "The title of the deploy key."},
+			{Name: "read_only", Type: proto.ColumnType_BOOL, Description: "If true, the deploy key can only read from the repository. Otherwise it can also push to it."},
+			{Name: "fingerprint", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key").Transform(sshKeyFingerprint), Description: "The SHA256 fingerprint of the public key."},
+
+			// Other columns
+			{Name: "key_type", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key").Transform(sshKeyType), Description: "The type of the public key, e.g. ssh-ed25519."},
+			{Name: "key", Type: proto.ColumnType_STRING, Description: "The public key."},
+			{Name: "verified", Type: proto.ColumnType_BOOL, Description: "If true, the deploy key has been verified."},
+			{Name: "added_by", Type: proto.ColumnType_STRING, Description: "The login of the user or the name of the app that added the deploy key."},
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the deploy key was created."},
+			{Name: "last_used", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("LastUsed").NullIfZero().Transform(convertTimestamp), Description: "Time when the deploy key was last used, or null if it has never been used."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "The REST API URL of the deploy key."},
+		}),
+	}
+}
+
+func tableGitHubRepositoryDeployKeyList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		keys, resp, err := client.Repositories.ListKeys(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range keys {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
+
+func tableGitHubRepositoryDeployKeyGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+	id := d.EqualsQuals["id"].GetInt64Value()
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	key, _, err := client.Repositories.GetKey(ctx, owner, repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
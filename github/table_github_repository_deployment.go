@@ -75,10 +75,13 @@ func tableGitHubRepositoryDeploymentList(ctx context.Context, d *plugin.QueryDat
 	}
 	appendRepoDeploymentColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_deployment", &query.RateLimit))
+		logRateLimit(ctx, d, "github_repository_deployment", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_repository_deployment", "api_error", err)
 			return nil, err
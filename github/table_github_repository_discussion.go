@@ -76,10 +76,13 @@ func tableGitHubRepositoryDiscussionList(ctx context.Context, d *plugin.QueryDat
 		"name":  githubv4.String(repoName),
 	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check repository and discussions status
-	err := client.Query(ctx, &checkQuery, checkVariables)
+	err = client.Query(ctx, &checkQuery, checkVariables)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_repository_discussion", "check_api_error", err, "repository", fullName)
 		return nil, err
@@ -114,7 +117,7 @@ func tableGitHubRepositoryDiscussionList(ctx context.Context, d *plugin.QueryDat
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_discussion", &query.RateLimit))
+		logRateLimit(ctx, d, "github_repository_discussion", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_repository_discussion", "api_error", err, "repository", fullName)
 			return nil, err
@@ -159,10 +162,13 @@ func tableGitHubRepositoryDiscussionGet(ctx context.Context, d *plugin.QueryData
 		"number": githubv4.Int(discussionNumber),
 	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_discussion", &query.RateLimit))
+	err = client.Query(ctx, &query, variables)
+	logRateLimit(ctx, d, "github_repository_discussion", &query.RateLimit)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_repository_discussion", "api_error", err)
 		return nil, err
@@ -198,12 +204,15 @@ func discussionHydrateComments(ctx context.Context, d *plugin.QueryData, h *plug
 		"cursor":   (*githubv4.String)(nil),
 	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	var allComments []models.DiscussionComment
 
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_discussion_comments", &query.RateLimit))
+		logRateLimit(ctx, d, "github_repository_discussion_comments", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_repository_discussion_comments", "api_error", err)
 			return nil, err
@@ -234,7 +243,10 @@ func discussionHydrateReplies(ctx context.Context, d *plugin.QueryData, h *plugi
 	}
 
 	pageSize := adjustPageSize(100, d.QueryContext.Limit)
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	// if the commentNodeIds is empty, we need to fetch all the comment node IDs from the discussion
 	// Step 1: Get all comment node IDs from the discussion
@@ -265,7 +277,7 @@ func discussionHydrateReplies(ctx context.Context, d *plugin.QueryData, h *plugi
 		// Collect all comment node IDs
 		for {
 			err := client.Query(ctx, &commentsQuery, commentsVariables)
-			plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_discussion_comments_for_replies", &commentsQuery.RateLimit))
+			logRateLimit(ctx, d, "github_repository_discussion_comments_for_replies", &commentsQuery.RateLimit)
 			if err != nil {
 				plugin.Logger(ctx).Error("github_repository_discussion_comments_for_replies", "api_error", err)
 				return nil, err
@@ -301,7 +313,7 @@ func discussionHydrateReplies(ctx context.Context, d *plugin.QueryData, h *plugi
 		// Get replies for this comment with pagination
 		for {
 			err := client.Query(ctx, &repliesQuery, repliesVariables)
-			plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_discussion_replies", &repliesQuery.RateLimit))
+			logRateLimit(ctx, d, "github_repository_discussion_replies", &repliesQuery.RateLimit)
 			if err != nil {
 				plugin.Logger(ctx).Error("github_repository_discussion_replies", "api_error", err)
 				return nil, err
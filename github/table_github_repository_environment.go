@@ -63,10 +63,13 @@ func tableGitHubRepositoryEnvironmentList(ctx context.Context, d *plugin.QueryDa
 	}
 	appendRepoEnvironmentColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_environment", &query.RateLimit))
+		logRateLimit(ctx, d, "github_repository_environment", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_repository_environment", "api_error", err)
 			return nil, err
@@ -90,12 +90,15 @@ func tableGitHubRepositoryRulesetList(ctx context.Context, d *plugin.QueryData,
 		"bypassActorCursor":   (*githubv4.String)(nil),
 	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	var rulesets []models.Ruleset
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_ruleset", &query.RateLimit))
+		logRateLimit(ctx, d, "github_repository_ruleset", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_repository_ruleset", "api_error", err)
 			return nil, err
@@ -184,7 +187,7 @@ func getAdditionalRules(ctx context.Context, d *plugin.QueryData, client *github
 	var rules []models.Rule
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_ruleset.getAdditionalRules", &query.RateLimit))
+		logRateLimit(ctx, d, "github_repository_ruleset.getAdditionalRules", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_repository_ruleset.getAdditionalRules", "api_error", err)
 			return nil
@@ -233,7 +236,7 @@ func getAdditionalBypassActors(ctx context.Context, d *plugin.QueryData, client
 	var bypassActors []models.BypassActor
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_repository_ruleset.getAdditionalBypassActors", &query.RateLimit))
+		logRateLimit(ctx, d, "github_repository_ruleset.getAdditionalBypassActors", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_repository_ruleset.getAdditionalBypassActors", "api_error", err)
 			return nil
@@ -85,7 +85,10 @@ func listRepositorySboms(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 	owner, repo = parseRepoFullName(fullName)
 	logger.Trace("tableGitHubRepositorySbomList", "owner", owner, "repo", repo)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	sbom, _, err := client.DependencyGraph.GetSBOM(ctx, owner, repo)
 	if err != nil {
 		logger.Error("github_repository_sbom.listRepositorySboms", "api_error", err)
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubRepositorySecretScanningAlert() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_repository_secret_scanning_alert",
+		Description: "Secret scanning alerts from a repository.",
+		List: &plugin.ListConfig{
+			KeyColumns: append([]*plugin.KeyColumn{
+				{
+					Name:    "repository_full_name",
+					Require: plugin.Required,
+				},
+			}, gitHubSecretScanningAlertKeyColumns()...),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubRepositorySecretScanningAlertList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns:        plugin.AllColumns([]string{"repository_full_name", "alert_number"}),
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubRepositorySecretScanningAlertGet,
+		},
+		Columns: commonColumns(append(
+			gitHubSecretScanningAlertColumns(),
+			[]*plugin.Column{
+				{
+					Name:        "repository_full_name",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromQual("repository_full_name"),
+					Description: "The full name of the repository (login/repo-name).",
+				},
+			}...,
+		)),
+	}
+}
+
+func tableGitHubRepositorySecretScanningAlertList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, listSecretScanningAlerts(ctx, d, client, fmt.Sprintf("repos/%s/%s/secret-scanning/alerts", owner, repo), secretScanningAlertListParams(d))
+}
+
+func tableGitHubRepositorySecretScanningAlertGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	alertNumber := d.EqualsQuals["alert_number"].GetInt64Value()
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+	plugin.Logger(ctx).Trace("tableGitHubRepositorySecretScanningAlertGet", "owner", owner, "repo", repo, "alertNumber", alertNumber)
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("repos/%s/%s/secret-scanning/alerts/%d?hide_secret=true", owner, repo, alertNumber), nil)
+	if err != nil {
+		return nil, err
+	}
+	var alert secretScanningAlert
+	if _, err := client.Do(ctx, req, &alert); err != nil {
+		return nil, err
+	}
+
+	return &alert, nil
+}
@@ -52,7 +52,10 @@ func tableGitHubSearchCommitList(ctx context.Context, d *plugin.QueryData, h *pl
 		TextMatch:   true,
 	}
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	// Reduce the basic request limit down if the user has only requested a small number of rows
 	limit := d.QueryContext.Limit
@@ -61,7 +61,10 @@ func tableGitHubSearchLabelList(ctx context.Context, d *plugin.QueryData, h *plu
 		}
 	}
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		result, resp, err := client.Search.Labels(ctx, repoId, query, opt)
 		if err != nil {
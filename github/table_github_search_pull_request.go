@@ -48,12 +48,17 @@ func tableGitHubSearchPullRequestList(ctx context.Context, d *plugin.QueryData,
 		"query":    githubv4.String(input),
 	}
 	appendPullRequestColumnIncludes(&variables, d.QueryContext.Columns)
-	appendUserInteractionAbilityForIssue(&variables, d.QueryContext.Columns, d)
+	if err := appendUserInteractionAbilityForIssue(ctx, &variables, d.QueryContext.Columns, d); err != nil {
+		return nil, err
+	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_search_pull_request", &query.RateLimit))
+		logRateLimit(ctx, d, "github_search_pull_request", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_search_pull_request", "api_error", err)
 			return nil, err
@@ -86,10 +86,13 @@ func tableGitHubSearchUserList(ctx context.Context, d *plugin.QueryData, h *plug
 		"query":    githubv4.String(input),
 	}
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_search_user", &query.RateLimit))
+		logRateLimit(ctx, d, "github_search_user", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_search_user", "api_error", err)
 			return nil, err
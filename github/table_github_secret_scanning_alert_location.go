@@ -0,0 +1,118 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubSecretScanningAlertLocation() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_secret_scanning_alert_location",
+		Description: "The locations, such as commits, issues and pull requests, where the secret of a secret scanning alert was found.",
+		List: &plugin.ListConfig{
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "repository_full_name", Require: plugin.Required},
+				{Name: "alert_number", Require: plugin.Optional},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			ParentHydrate:     tableGitHubSecretScanningAlertLocationAlertList,
+			Hydrate:           tableGitHubSecretScanningAlertLocationList,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository of the alert."},
+			{Name: "alert_number", Type: proto.ColumnType_INT, Description: "The number of the secret scanning alert."},
+			{Name: "type", Type: proto.ColumnType_STRING, Transform: transform.FromField("Location.Type"), Description: "The type of the location, e.g. commit, issue_body or pull_request_comment."},
+			{Name: "path", Type: proto.ColumnType_STRING, Transform: transform.FromField("Location.Details.Path"), Description: "The path of the file the secret was found in, for a commit location."},
+			{Name: "start_line", Type: proto.ColumnType_INT, Transform: transform.FromField("Location.Details.Startline"), Description: "The line the secret starts at, for a commit location."},
+			{Name: "end_line", Type: proto.ColumnType_INT, Transform: transform.FromField("Location.Details.EndLine"), Description: "The line the secret ends at, for a commit location."},
+			{Name: "start_column", Type: proto.ColumnType_INT, Transform: transform.FromField("Location.Details.StartColumn"), Description: "The column the secret starts at, for a commit location."},
+			{Name: "end_column", Type: proto.ColumnType_INT, Transform: transform.FromField("Location.Details.EndColumn"), Description: "The column the secret ends at, for a commit location."},
+			{Name: "commit_sha", Type: proto.ColumnType_STRING, Transform: transform.FromField("Location.Details.CommitSHA"), Description: "The SHA of the commit the secret was found in, for a commit location."},
+
+			// Other columns
+			{Name: "commit_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("Location.Details.CommitURL"), Description: "The REST API URL of the commit the secret was found in."},
+			{Name: "blob_sha", Type: proto.ColumnType_STRING, Transform: transform.FromField("Location.Details.BlobSHA"), Description: "The SHA of the blob the secret was found in."},
+			{Name: "blob_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("Location.Details.BlobURL"), Description: "The REST API URL of the blob the secret was found in."},
+		}),
+	}
+}
+
+type secretScanningAlertLocationInfo struct {
+	AlertNumber int
+	Location    *github.SecretScanningAlertLocation
+}
+
+// tableGitHubSecretScanningAlertLocationAlertList lists the alerts whose
+// locations are listed, which is only the alert of the alert_number
+// qualifier if it is set.
+func tableGitHubSecretScanningAlertLocationAlertList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	if d.EqualsQuals["alert_number"] != nil {
+		number := int(d.EqualsQuals["alert_number"].GetInt64Value())
+		d.StreamListItem(ctx, &secretScanningAlert{SecretScanningAlert: github.SecretScanningAlert{Number: &number}})
+		return nil, nil
+	}
+
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// The limit is on the locations, so it doesn't bound the alerts listed
+	params := url.Values{}
+	params.Set("hide_secret", "true")
+	params.Set("per_page", "100")
+	return nil, listSecretScanningAlerts(ctx, d, client, fmt.Sprintf("repos/%s/%s/secret-scanning/alerts", owner, repo), params)
+}
+
+func tableGitHubSecretScanningAlertLocationList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	alert := h.Item.(*secretScanningAlert)
+	owner, repo := parseRepoFullName(d.EqualsQualString("repository_full_name"))
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		locations, resp, err := client.SecretScanning.ListLocationsForAlert(ctx, owner, repo, int64(alert.GetNumber()), opts)
+		if err != nil {
+			// In the case of parent hydrate the ignore config seems to not work for the child table. So we need to handle it manually.
+			// Steampipe SDK issue ref: https://github.com/turbot/steampipe-plugin-sdk/issues/544
+			if strings.Contains(err.Error(), "404") {
+				return nil, nil
+			}
+
+			plugin.Logger(ctx).Error("github_secret_scanning_alert_location.tableGitHubSecretScanningAlertLocationList", "api_error", err)
+			return nil, err
+		}
+
+		for _, location := range locations {
+			d.StreamListItem(ctx, secretScanningAlertLocationInfo{alert.GetNumber(), location})
+
+			// Stop if we've hit the limit set in the query context
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
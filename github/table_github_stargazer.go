@@ -60,10 +60,13 @@ func tableGitHubStargazerList(ctx context.Context, d *plugin.QueryData, h *plugi
 	}
 	appendStargazerColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_stargazer", &query.RateLimit))
+		logRateLimit(ctx, d, "github_stargazer", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_stargazer", "api_error", err)
 			return nil, err
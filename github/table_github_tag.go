@@ -57,10 +57,13 @@ func tableGitHubTagList(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	}
 	appendTagColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_tag", &query.RateLimit))
+		logRateLimit(ctx, d, "github_tag", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_tag", "api_error", err)
 			return nil, err
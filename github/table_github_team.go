@@ -87,10 +87,13 @@ func tableGitHubTeamList(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 	}
 	appendTeamColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_team", &query.RateLimit))
+		logRateLimit(ctx, d, "github_team", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_team", "api_error", err)
 			if strings.Contains(err.Error(), "Could not resolve to an Organization with the login of") {
@@ -135,9 +138,12 @@ func tableGitHubTeamGet(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 	}
 	appendTeamColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_team", &query.RateLimit))
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	err = client.Query(ctx, &query, variables)
+	logRateLimit(ctx, d, "github_team", &query.RateLimit)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_team", "api_error", err)
 		if strings.Contains(err.Error(), "Could not resolve to an Organization with the login of") {
@@ -71,10 +71,13 @@ func tableGitHubTeamMemberList(ctx context.Context, d *plugin.QueryData, _ *plug
 	}
 	appendUserColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_team_member", &query.RateLimit))
+		logRateLimit(ctx, d, "github_team_member", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_team_member", "api_error", err)
 			if strings.Contains(err.Error(), "Could not resolve to an Organization with the login of") {
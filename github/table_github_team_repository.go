@@ -73,10 +73,13 @@ func tableGitHubTeamRepositoryList(ctx context.Context, d *plugin.QueryData, h *
 	}
 	appendRepoColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	for {
 		err := client.Query(ctx, &query, variables)
-		plugin.Logger(ctx).Debug(rateLimitLogString("github_team_repository", &query.RateLimit))
+		logRateLimit(ctx, d, "github_team_repository", &query.RateLimit)
 		if err != nil {
 			plugin.Logger(ctx).Error("github_team_repository", "api_error", err)
 			if strings.Contains(err.Error(), "Could not resolve to an Organization with the login of") {
@@ -129,9 +132,12 @@ func tableGitHubTeamRepositoryGet(ctx context.Context, d *plugin.QueryData, h *p
 	}
 	appendRepoColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_team_repository", &query.RateLimit))
+	client, err := connectV4(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	err = client.Query(ctx, &query, variables)
+	logRateLimit(ctx, d, "github_team_repository", &query.RateLimit)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_team_repository", "api_error", err)
 		if strings.Contains(err.Error(), "Could not resolve to an Organization with the login of") {
@@ -0,0 +1,59 @@
+package github
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubTokenPermission() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_token_permission",
+		Description: "Scopes and permissions of the credentials the connection authenticates with, and the tables they can query.",
+		List: &plugin.ListConfig{
+			Hydrate: listGitHubTokenPermissions,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "credential", Type: proto.ColumnType_STRING, Description: "The label of the credential in the connection, e.g. token 0 or installation 1234. Tokens are numbered in the order they are configured, starting at 0 with the token argument."},
+			{Name: "token_type", Type: proto.ColumnType_STRING, Description: "The kind of credential, one of classic_personal_access_token, fine_grained_personal_access_token, oauth_access_token, installation_access_token or app_installation."},
+			{Name: "scopes", Type: proto.ColumnType_JSON, Description: "The OAuth scopes granted to a classic personal access token or OAuth access token, from the X-OAuth-Scopes header. Null for other kinds of credential."},
+			{Name: "permissions", Type: proto.ColumnType_JSON, Description: "The permissions granted to the credential, keyed by permission name. GitHub doesn't expose the permissions of fine-grained personal access tokens and installation access tokens, so for those only the read permissions found by probing the API are listed. Null for classic and OAuth tokens."},
+
+			// Other columns
+			{Name: "expires_at", Type: proto.ColumnType_TIMESTAMP, Description: "Time when the token expires, if it has an expiration."},
+			{Name: "installation_id", Type: proto.ColumnType_INT, Transform: transform.FromField("InstallationID"), Description: "The ID of the GitHub App installation the credential authenticates as."},
+			{Name: "repository_selection", Type: proto.ColumnType_STRING, Description: "Whether an installation can access all repositories of the account, or only selected ones."},
+			{Name: "table_access", Type: proto.ColumnType_JSON, Description: "Whether each table of the plugin can be queried with the credential, keyed by table name. The value is null when it can't be determined from the credential's scopes or permissions."},
+		}),
+	}
+}
+
+func listGitHubTokenPermissions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	config, err := getClientConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := getCredentialPool(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range pool.credentials {
+		permission, err := getTokenPermission(ctx, d, config, c)
+		if err != nil {
+			plugin.Logger(ctx).Error("github_token_permission.listGitHubTokenPermissions", "credential", c.name, "api_error", err)
+			return nil, err
+		}
+		d.StreamListItem(ctx, permission)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
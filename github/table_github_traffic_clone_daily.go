@@ -30,7 +30,10 @@ func tableGitHubTrafficCloneDaily() *plugin.Table {
 }
 
 func tableGitHubTrafficCloneDailyList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	fullName := d.EqualsQuals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(fullName)
 	opts := &github.TrafficBreakdownOptions{Per: "day"}
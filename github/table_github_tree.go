@@ -48,7 +48,10 @@ type treeEntry struct {
 
 func tableGitHubTreeList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	logger := plugin.Logger(ctx)
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	quals := d.EqualsQuals
 	fullName := quals["repository_full_name"].GetStringValue()
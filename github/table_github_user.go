@@ -4,9 +4,6 @@ import (
 	"context"
 	"strings"
 
-	"github.com/shurcooL/githubv4"
-	"github.com/turbot/steampipe-plugin-github/github/models"
-
 	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
@@ -102,19 +99,10 @@ func sharedUserColumns() []*plugin.Column {
 func tableGitHubUserGet(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 	login := d.EqualsQuals["login"].GetStringValue()
 
-	var query struct {
-		RateLimit models.RateLimit
-		User      models.UserWithCounts `graphql:"user(login: $login)"`
-	}
-
-	variables := map[string]interface{}{
-		"login": githubv4.String(login),
-	}
+	variables := map[string]interface{}{}
 	appendUserWithCountColumnIncludes(&variables, d.QueryContext.Columns)
 
-	client := connectV4(ctx, d)
-	err := client.Query(ctx, &query, variables)
-	plugin.Logger(ctx).Debug(rateLimitLogString("github_user", &query.RateLimit))
+	user, err := userLookup.load(ctx, d, variables, login)
 	if err != nil {
 		plugin.Logger(ctx).Error("github_user", "api_error", err)
 		if strings.Contains(err.Error(), "Could not resolve to a User with the login of") {
@@ -122,8 +110,9 @@ func tableGitHubUserGet(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		}
 		return nil, err
 	}
-
-	d.StreamListItem(ctx, query.User)
+	if user != nil {
+		d.StreamListItem(ctx, user)
+	}
 
 	return nil, nil
 }
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubUserGPGKey() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_user_gpg_key",
+		Description: "GPG keys that a user signs commits and tags with. Lists the keys of the authenticated user unless a login is given.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "login", Require: plugin.Optional},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubUserGPGKeyList,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "login", Type: proto.ColumnType_STRING, Description: "The login of the user the key belongs to."},
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromField("Key.ID"), Description: "Unique ID of the key."},
+			{Name: "key_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.KeyID"), Description: "The 64-bit key ID of the key, in hex."},
+			{Name: "fingerprint", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.PublicKey").Transform(gpgKeyFingerprint), Description: "The fingerprint of the primary key, in hex."},
+			{Name: "expires_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Key.ExpiresAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the key expires, or null if it never expires."},
+			{Name: "emails", Type: proto.ColumnType_JSON, Transform: transform.FromField("Key.Emails"), Description: "The email addresses of the key, and whether each has been verified."},
+
+			// Other columns
+			{Name: "primary_key_id", Type: proto.ColumnType_INT, Transform: transform.FromField("Key.PrimaryKeyID"), Description: "The ID of the primary key of a subkey, which is null for a primary key."},
+			{Name: "can_sign", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Key.CanSign"), Description: "If true, the key can sign."},
+			{Name: "can_certify", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Key.CanCertify"), Description: "If true, the key can certify other keys."},
+			{Name: "can_encrypt_comms", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Key.CanEncryptComms"), Description: "If true, the key can encrypt communications."},
+			{Name: "can_encrypt_storage", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Key.CanEncryptStorage"), Description: "If true, the key can encrypt storage."},
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Key.CreatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the key was created."},
+			{Name: "subkeys", Type: proto.ColumnType_JSON, Transform: transform.FromField("Key.Subkeys").Transform(gpgSubkeys), Description: "The subkeys of the key, with their capabilities, expiry and fingerprints."},
+			{Name: "raw_key", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.RawKey"), Description: "The ASCII armored public key, as it was added."},
+		}),
+	}
+}
+
+type userGPGKeyInfo struct {
+	Login string
+	Key   *github.GPGKey
+}
+
+func tableGitHubUserGPGKeyList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	login, user, err := keyOwner(ctx, d, client)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		keys, resp, err := client.Users.ListGPGKeys(ctx, user, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range keys {
+			d.StreamListItem(ctx, userGPGKeyInfo{login, i})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
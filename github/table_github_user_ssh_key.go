@@ -0,0 +1,83 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubUserSSHKey() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_user_ssh_key",
+		Description: "SSH keys that authenticate a user for Git operations. Lists the keys of the authenticated user unless a login is given.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "login", Require: plugin.Optional},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubUserSSHKeyList,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "login", Type: proto.ColumnType_STRING, Description: "The login of the user the key belongs to."},
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromField("Key.ID"), Description: "Unique ID of the key."},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.Title"), Description: "The title of the key. Only returned for the keys of the authenticated user."},
+			{Name: "fingerprint", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.Key").Transform(sshKeyFingerprint), Description: "The SHA256 fingerprint of the public key."},
+			{Name: "key_type", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.Key").Transform(sshKeyType), Description: "The type of the public key, e.g. ssh-ed25519."},
+
+			// Other columns
+			{Name: "key", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.Key"), Description: "The public key."},
+			{Name: "verified", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Key.Verified"), Description: "If true, the key has been verified. Only returned for the keys of the authenticated user."},
+			{Name: "read_only", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Key.ReadOnly"), Description: "If true, the key can only be used for read operations. Only returned for the keys of the authenticated user."},
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Key.CreatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the key was added. Only returned for the keys of the authenticated user."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.URL"), Description: "The REST API URL of the key. Only returned for the keys of the authenticated user."},
+		}),
+	}
+}
+
+type userSSHKeyInfo struct {
+	Login string
+	Key   *github.Key
+}
+
+func tableGitHubUserSSHKeyList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	login, user, err := keyOwner(ctx, d, client)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		keys, resp, err := client.Users.ListKeys(ctx, user, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range keys {
+			d.StreamListItem(ctx, userSSHKeyInfo{login, i})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubUserSSHSigningKey() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_user_ssh_signing_key",
+		Description: "SSH keys that a user signs commits and tags with. Lists the keys of the authenticated user unless a login is given.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "login", Require: plugin.Optional},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubUserSSHSigningKeyList,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "login", Type: proto.ColumnType_STRING, Description: "The login of the user the key belongs to."},
+			{Name: "id", Type: proto.ColumnType_INT, Transform: transform.FromField("Key.ID"), Description: "Unique ID of the key."},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.Title"), Description: "The title of the key."},
+			{Name: "fingerprint", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.Key").Transform(sshKeyFingerprint), Description: "The SHA256 fingerprint of the public key."},
+			{Name: "key_type", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.Key").Transform(sshKeyType), Description: "The type of the public key, e.g. ssh-ed25519."},
+
+			// Other columns
+			{Name: "key", Type: proto.ColumnType_STRING, Transform: transform.FromField("Key.Key"), Description: "The public key."},
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Key.CreatedAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the key was added."},
+		}),
+	}
+}
+
+type userSSHSigningKeyInfo struct {
+	Login string
+	Key   *github.SSHSigningKey
+}
+
+func tableGitHubUserSSHSigningKeyList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	login, user, err := keyOwner(ctx, d, client)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &github.ListOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		keys, resp, err := client.Users.ListSSHSigningKeys(ctx, user, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range keys {
+			d.StreamListItem(ctx, userSSHSigningKeyInfo{login, i})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v55/github"
+
+	"github.com/turbot/steampipe-plugin-sdk/v6/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin/transform"
+)
+
+func tableGitHubWebhookDelivery() *plugin.Table {
+	return &plugin.Table{
+		Name:        "github_webhook_delivery",
+		Description: "Recent deliveries of the events of a repository or organization webhook.",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "hook_id", Require: plugin.Required},
+				{Name: "repository_full_name", Require: plugin.AnyOf},
+				{Name: "organization", Require: plugin.AnyOf},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubWebhookDeliveryList,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "hook_id", Require: plugin.Required},
+				{Name: "id", Require: plugin.Required},
+				{Name: "repository_full_name", Require: plugin.AnyOf},
+				{Name: "organization", Require: plugin.AnyOf},
+			},
+			ShouldIgnoreError: isNotFoundError([]string{"404"}),
+			Hydrate:           tableGitHubWebhookDeliveryGet,
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "repository_full_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("repository_full_name"), Description: "Full name of the repository of the webhook, for a repository webhook."},
+			{Name: "organization", Type: proto.ColumnType_STRING, Transform: transform.FromQual("organization"), Description: "The login name of the organization of the webhook, for an organization webhook."},
+			{Name: "hook_id", Type: proto.ColumnType_INT, Transform: transform.FromQual("hook_id"), Description: "Unique ID of the webhook."},
+			{Name: "id", Type: proto.ColumnType_INT, Description: "Unique ID of the delivery."},
+			{Name: "event", Type: proto.ColumnType_STRING, Description: "The event that was delivered, e.g. push."},
+			{Name: "status_code", Type: proto.ColumnType_INT, Description: "The HTTP status code the delivery was answered with, or 0 if it got no response."},
+			{Name: "delivered_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("DeliveredAt").NullIfZero().Transform(convertTimestamp), Description: "Time when the delivery was made."},
+
+			// Other columns
+			{Name: "guid", Type: proto.ColumnType_STRING, Transform: transform.FromField("GUID"), Description: "The GUID of the event, which redeliveries share with the original delivery."},
+			{Name: "action", Type: proto.ColumnType_STRING, Description: "The action of the event, e.g. opened for a pull_request event."},
+			{Name: "status", Type: proto.ColumnType_STRING, Description: "The description of the status of the delivery, e.g. OK or the error it failed with."},
+			{Name: "redelivery", Type: proto.ColumnType_BOOL, Description: "If true, the delivery is a redelivery of an earlier one."},
+			{Name: "duration", Type: proto.ColumnType_DOUBLE, Description: "The time the delivery took, in seconds."},
+			{Name: "installation_id", Type: proto.ColumnType_INT, Transform: transform.FromField("InstallationID"), Description: "The ID of the GitHub App installation the event is for, if any."},
+			{Name: "repository_id", Type: proto.ColumnType_INT, Transform: transform.FromField("RepositoryID"), Description: "The ID of the repository the event is for, if any."},
+			{Name: "request_headers", Type: proto.ColumnType_JSON, Hydrate: tableGitHubWebhookDeliveryGet, Transform: transform.FromField("Request.Headers"), Description: "The headers of the request the event was delivered with."},
+			{Name: "response_headers", Type: proto.ColumnType_JSON, Hydrate: tableGitHubWebhookDeliveryGet, Transform: transform.FromField("Response.Headers"), Description: "The headers of the response the delivery was answered with."},
+		}),
+	}
+}
+
+func tableGitHubWebhookDeliveryList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	hookID := d.EqualsQuals["hook_id"].GetInt64Value()
+	fullName := d.EqualsQualString("repository_full_name")
+	org := d.EqualsQualString("organization")
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &github.ListCursorOptions{PerPage: adjustPageSize(100, d.QueryContext.Limit)}
+
+	for {
+		var deliveries []*github.HookDelivery
+		var resp *github.Response
+		if fullName != "" {
+			owner, repo := parseRepoFullName(fullName)
+			deliveries, resp, err = client.Repositories.ListHookDeliveries(ctx, owner, repo, hookID, opt)
+		} else {
+			deliveries, resp, err = client.Organizations.ListHookDeliveries(ctx, org, hookID, opt)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range deliveries {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if resp.Cursor == "" {
+			break
+		}
+
+		opt.Cursor = resp.Cursor
+	}
+
+	return nil, nil
+}
+
+// tableGitHubWebhookDeliveryGet gets a delivery with its request and
+// response, which listing the deliveries leaves out.
+func tableGitHubWebhookDeliveryGet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	hookID := d.EqualsQuals["hook_id"].GetInt64Value()
+	fullName := d.EqualsQualString("repository_full_name")
+	org := d.EqualsQualString("organization")
+
+	var id int64
+	if h.Item != nil {
+		id = h.Item.(*github.HookDelivery).GetID()
+	} else {
+		id = d.EqualsQuals["id"].GetInt64Value()
+	}
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	var delivery *github.HookDelivery
+	switch {
+	case fullName != "":
+		owner, repo := parseRepoFullName(fullName)
+		delivery, _, err = client.Repositories.GetHookDelivery(ctx, owner, repo, hookID, id)
+	case org != "":
+		delivery, _, err = client.Organizations.GetHookDelivery(ctx, org, hookID, id)
+	default:
+		return nil, fmt.Errorf("'repository_full_name' or 'organization' must be specified in the where clause")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
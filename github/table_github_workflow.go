@@ -59,7 +59,10 @@ type FileContent struct {
 }
 
 func tableGitHubWorkflowList(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	fullName := d.EqualsQuals["repository_full_name"].GetStringValue()
 	owner, repo := parseRepoFullName(fullName)
 	opts := &github.ListOptions{PerPage: 100}
@@ -104,7 +107,10 @@ func tableGitHubWorkflowGet(ctx context.Context, d *plugin.QueryData, h *plugin.
 	owner, repo := parseRepoFullName(fullName)
 	plugin.Logger(ctx).Trace("tableGitHubWorkflowGet", "owner", owner, "repo", repo, "id", id)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 	workflow, _, err := client.Actions.GetWorkflowByID(ctx, owner, repo, id)
 	if err != nil {
 		return nil, err
@@ -124,7 +130,10 @@ func GitHubWorkflowFileContent(ctx context.Context, d *plugin.QueryData, h *plug
 	owner, repo := parseRepoFullName(fullName)
 	plugin.Logger(ctx).Trace("tableGitHubWorkflowGet", "owner", owner, "repo", repo, "id", id)
 
-	client := connect(ctx, d)
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get the name of the default branch for the repository
 	workflowUrlParts := strings.Split(*workflow.HTMLURL, "/")
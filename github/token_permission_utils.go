@@ -0,0 +1,502 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/turbot/steampipe-plugin-sdk/v6/plugin"
+)
+
+// credentialKind is the kind of token a credential authenticates with, which
+// determines how its scopes or permissions can be discovered.
+type credentialKind string
+
+const (
+	credentialKindClassicPersonalAccessToken     credentialKind = "classic_personal_access_token"
+	credentialKindFineGrainedPersonalAccessToken credentialKind = "fine_grained_personal_access_token"
+	credentialKindOAuthAccessToken               credentialKind = "oauth_access_token"
+	credentialKindInstallationAccessToken        credentialKind = "installation_access_token"
+	credentialKindAppInstallation                credentialKind = "app_installation"
+)
+
+// tokenCredentialKind returns the kind of a token from its prefix, see
+// https://github.blog/2021-04-05-behind-githubs-new-authentication-token-formats/
+func tokenCredentialKind(token string) credentialKind {
+	switch {
+	case strings.HasPrefix(token, "github_pat"):
+		return credentialKindFineGrainedPersonalAccessToken
+	case isValidPersonalAccessTokenPrefix(token):
+		return credentialKindClassicPersonalAccessToken
+	case strings.HasPrefix(token, "gho_"):
+		return credentialKindOAuthAccessToken
+	default:
+		return credentialKindInstallationAccessToken
+	}
+}
+
+// isInstallation returns whether the credential authenticates as a GitHub
+// App installation, which has no authenticated user.
+func (k credentialKind) isInstallation() bool {
+	return k == credentialKindAppInstallation || k == credentialKindInstallationAccessToken
+}
+
+// tokenPermission is the access a single credential of the connection has.
+// Scopes are only known for classic and OAuth tokens, and permissions for
+// GitHub App installations. GitHub doesn't expose the permissions of
+// fine-grained personal access tokens or bare installation access tokens, so
+// their read permissions are derived from access probes.
+type tokenPermission struct {
+	Credential          string
+	TokenType           credentialKind
+	InstallationID      *int64
+	Scopes              []string
+	Permissions         map[string]string
+	RepositorySelection *string
+	ExpiresAt           *time.Time
+	TableAccess         map[string]*bool
+
+	// probed is the set of permissions a probe could tell were granted or
+	// not, when the permissions were derived from probes
+	probed map[string]bool
+}
+
+// tablePermissions is what a credential needs to query a table. A classic
+// token needs any one of the Scopes, while fine-grained tokens and GitHub
+// Apps need every one of the Permissions, with at least the given access
+// level. A table with only Scopes, like the enterprise tables, can only be
+// queried with a classic token. A table with neither only reads public data.
+type tablePermissions struct {
+	Scopes      []string
+	Permissions map[string]string
+}
+
+var repoContentsPermissions = tablePermissions{Scopes: []string{"repo"}, Permissions: map[string]string{"contents": "read"}}
+var repoMetadataPermissions = tablePermissions{Scopes: []string{"repo"}, Permissions: map[string]string{"metadata": "read"}}
+var repoActionsPermissions = tablePermissions{Scopes: []string{"repo"}, Permissions: map[string]string{"actions": "read"}}
+var repoAdministrationPermissions = tablePermissions{Scopes: []string{"repo"}, Permissions: map[string]string{"administration": "read"}}
+var repoIssuesPermissions = tablePermissions{Scopes: []string{"repo"}, Permissions: map[string]string{"issues": "read"}}
+var repoChecksPermissions = tablePermissions{Scopes: []string{"repo"}, Permissions: map[string]string{"checks": "read"}}
+var repoPullRequestsPermissions = tablePermissions{Scopes: []string{"repo"}, Permissions: map[string]string{"pull_requests": "read"}}
+var codeScanningAlertPermissions = tablePermissions{Scopes: []string{"repo", "security_events"}, Permissions: map[string]string{"security_events": "read"}}
+var secretScanningAlertPermissions = tablePermissions{Scopes: []string{"repo", "security_events"}, Permissions: map[string]string{"secret_scanning_alerts": "read"}}
+var dependabotAlertPermissions = tablePermissions{Scopes: []string{"repo", "security_events"}, Permissions: map[string]string{"vulnerability_alerts": "read"}}
+var orgSelfHostedRunnersPermissions = tablePermissions{Scopes: []string{"admin:org"}, Permissions: map[string]string{"organization_self_hosted_runners": "read"}}
+var enterpriseRunnersPermissions = tablePermissions{Scopes: []string{"manage_runners:enterprise"}}
+var orgMembersPermissions = tablePermissions{Scopes: []string{"read:org"}, Permissions: map[string]string{"members": "read"}}
+var packagesPermissions = tablePermissions{Scopes: []string{"read:packages"}, Permissions: map[string]string{"packages": "read"}}
+var publicPermissions = tablePermissions{}
+
+// requiredTablePermissions maps the tables of the plugin to the scopes and
+// permissions they need. Tables that can't be queried with any of the
+// credential kinds in the map, like github_app_installation which needs the
+// app's own JWT, are left out and reported as unknown.
+var requiredTablePermissions = map[string]tablePermissions{
+	"github_actions_artifact":                   repoActionsPermissions,
+	"github_actions_enterprise_runner":          enterpriseRunnersPermissions,
+	"github_actions_enterprise_runner_group":    enterpriseRunnersPermissions,
+	"github_actions_environment_secret":         {Scopes: []string{"repo"}, Permissions: map[string]string{"secrets": "read"}},
+	"github_actions_environment_variable":       {Scopes: []string{"repo"}, Permissions: map[string]string{"environments": "read"}},
+	"github_actions_organization_permissions":   {Scopes: []string{"admin:org"}, Permissions: map[string]string{"organization_administration": "read"}},
+	"github_actions_organization_runner":        orgSelfHostedRunnersPermissions,
+	"github_actions_organization_secret":        {Scopes: []string{"admin:org"}, Permissions: map[string]string{"organization_secrets": "read"}},
+	"github_actions_organization_variable":      {Scopes: []string{"admin:org"}, Permissions: map[string]string{"organization_actions_variables": "read"}},
+	"github_actions_repository_permissions":     repoAdministrationPermissions,
+	"github_actions_repository_runner":          repoAdministrationPermissions,
+	"github_actions_repository_secret":          {Scopes: []string{"repo"}, Permissions: map[string]string{"secrets": "read"}},
+	"github_actions_repository_variable":        {Scopes: []string{"repo"}, Permissions: map[string]string{"actions_variables": "read"}},
+	"github_actions_repository_workflow_job":    repoActionsPermissions,
+	"github_actions_repository_workflow_run":    repoActionsPermissions,
+	"github_actions_runner_group":               orgSelfHostedRunnersPermissions,
+	"github_actions_runner_group_repository":    orgSelfHostedRunnersPermissions,
+	"github_actions_workflow_job_log":           repoActionsPermissions,
+	"github_audit_log":                          {Scopes: []string{"read:audit_log"}, Permissions: map[string]string{"organization_administration": "read"}},
+	"github_blob":                               repoContentsPermissions,
+	"github_branch":                             repoContentsPermissions,
+	"github_branch_protection":                  repoAdministrationPermissions,
+	"github_check_run":                          repoChecksPermissions,
+	"github_check_run_annotation":               repoChecksPermissions,
+	"github_check_suite":                        repoChecksPermissions,
+	"github_code_owner":                         repoContentsPermissions,
+	"github_code_scanning_analysis":             codeScanningAlertPermissions,
+	"github_codespaces_organization_secret":     {Scopes: []string{"admin:org"}, Permissions: map[string]string{"organization_codespaces_secrets": "read"}},
+	"github_commit":                             repoContentsPermissions,
+	"github_commit_status":                      {Scopes: []string{"repo"}, Permissions: map[string]string{"statuses": "read"}},
+	"github_community_profile":                  repoMetadataPermissions,
+	"github_dependabot_organization_secret":     {Scopes: []string{"admin:org"}, Permissions: map[string]string{"organization_dependabot_secrets": "read"}},
+	"github_dependabot_repository_secret":       {Scopes: []string{"repo"}, Permissions: map[string]string{"dependabot_secrets": "read"}},
+	"github_gist":                               {Scopes: []string{"gist"}, Permissions: map[string]string{"gists": "read"}},
+	"github_gitignore":                          publicPermissions,
+	"github_issue":                              repoIssuesPermissions,
+	"github_issue_comment":                      repoIssuesPermissions,
+	"github_license":                            publicPermissions,
+	"github_my_gist":                            {Scopes: []string{"gist"}, Permissions: map[string]string{"gists": "read"}},
+	"github_my_issue":                           repoIssuesPermissions,
+	"github_my_organization":                    orgMembersPermissions,
+	"github_my_repository":                      repoMetadataPermissions,
+	"github_my_star":                            {Permissions: map[string]string{"starring": "read"}},
+	"github_my_team":                            orgMembersPermissions,
+	"github_organization":                       orgMembersPermissions,
+	"github_organization_collaborator":          orgMembersPermissions,
+	"github_organization_code_scanning_alert":   codeScanningAlertPermissions,
+	"github_organization_dependabot_alert":      dependabotAlertPermissions,
+	"github_organization_external_identity":     {Scopes: []string{"admin:org"}, Permissions: map[string]string{"members": "read"}},
+	"github_organization_member":                orgMembersPermissions,
+	"github_organization_ruleset":               {Scopes: []string{"admin:org"}, Permissions: map[string]string{"organization_administration": "read"}},
+	"github_organization_secret_repository":     {Scopes: []string{"admin:org"}, Permissions: map[string]string{"organization_secrets": "read"}},
+	"github_organization_secret_scanning_alert": secretScanningAlertPermissions,
+	"github_organization_webhook":               {Scopes: []string{"admin:org_hook"}, Permissions: map[string]string{"organization_hooks": "read"}},
+	"github_package":                            packagesPermissions,
+	"github_package_version":                    packagesPermissions,
+	"github_pull_request":                       repoPullRequestsPermissions,
+	"github_pull_request_comment":               repoPullRequestsPermissions,
+	"github_pull_request_diff_hunk":             repoPullRequestsPermissions,
+	"github_pull_request_file":                  repoPullRequestsPermissions,
+	"github_pull_request_review":                repoPullRequestsPermissions,
+	"github_rate_limit":                         publicPermissions,
+	"github_rate_limit_graphql":                 publicPermissions,
+	"github_release":                            repoContentsPermissions,
+	"github_repository":                         repoMetadataPermissions,
+	"github_repository_code_scanning_alert":     codeScanningAlertPermissions,
+	"github_repository_collaborator":            repoMetadataPermissions,
+	"github_repository_content":                 repoContentsPermissions,
+	"github_repository_dependabot_alert":        dependabotAlertPermissions,
+	"github_repository_deploy_key":              repoAdministrationPermissions,
+	"github_repository_deployment":              {Scopes: []string{"repo", "repo_deployment"}, Permissions: map[string]string{"deployments": "read"}},
+	"github_repository_discussion":              {Scopes: []string{"repo"}, Permissions: map[string]string{"discussions": "read"}},
+	"github_repository_environment":             repoActionsPermissions,
+	"github_repository_ruleset":                 repoAdministrationPermissions,
+	"github_repository_sbom":                    repoContentsPermissions,
+	"github_repository_secret_scanning_alert":   secretScanningAlertPermissions,
+	"github_repository_vulnerability_alert":     dependabotAlertPermissions,
+	"github_repository_webhook":                 {Scopes: []string{"admin:repo_hook", "read:repo_hook", "repo"}, Permissions: map[string]string{"repository_hooks": "read"}},
+	"github_search_code":                        repoContentsPermissions,
+	"github_search_commit":                      repoContentsPermissions,
+	"github_search_issue":                       repoIssuesPermissions,
+	"github_search_label":                       repoMetadataPermissions,
+	"github_search_pull_request":                repoPullRequestsPermissions,
+	"github_search_repository":                  repoMetadataPermissions,
+	"github_search_topic":                       publicPermissions,
+	"github_search_user":                        publicPermissions,
+	"github_secret_scanning_alert_location":     secretScanningAlertPermissions,
+	"github_stargazer":                          repoMetadataPermissions,
+	"github_tag":                                repoContentsPermissions,
+	"github_team":                               orgMembersPermissions,
+	"github_team_member":                        orgMembersPermissions,
+	"github_team_repository":                    orgMembersPermissions,
+	"github_token_permission":                   publicPermissions,
+	"github_traffic_clone_daily":                repoAdministrationPermissions,
+	"github_traffic_clone_weekly":               repoAdministrationPermissions,
+	"github_traffic_view_daily":                 repoAdministrationPermissions,
+	"github_traffic_view_weekly":                repoAdministrationPermissions,
+	"github_tree":                               repoContentsPermissions,
+	"github_user":                               publicPermissions,
+	"github_user_gpg_key":                       {Scopes: []string{"read:gpg_key"}, Permissions: map[string]string{"gpg_keys": "read"}},
+	"github_user_ssh_key":                       {Scopes: []string{"read:public_key"}, Permissions: map[string]string{"git_ssh_keys": "read"}},
+	"github_user_ssh_signing_key":               {Scopes: []string{"read:ssh_signing_key"}, Permissions: map[string]string{"ssh_signing_keys": "read"}},
+	"github_webhook_delivery":                   {Scopes: []string{"admin:repo_hook", "read:repo_hook", "admin:org_hook"}, Permissions: map[string]string{"repository_hooks": "read"}},
+	"github_workflow":                           repoActionsPermissions,
+}
+
+// impliedScopes lists the classic token scopes that are granted by a broader
+// scope, see https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/scopes-for-oauth-apps
+var impliedScopes = map[string][]string{
+	"repo":                      {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:org":                 {"write:org", "read:org"},
+	"write:org":                 {"read:org"},
+	"admin:public_key":          {"write:public_key", "read:public_key"},
+	"write:public_key":          {"read:public_key"},
+	"admin:repo_hook":           {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook":           {"read:repo_hook"},
+	"user":                      {"read:user", "user:email", "user:follow"},
+	"write:packages":            {"read:packages"},
+	"admin:gpg_key":             {"write:gpg_key", "read:gpg_key"},
+	"write:gpg_key":             {"read:gpg_key"},
+	"admin:ssh_signing_key":     {"write:ssh_signing_key", "read:ssh_signing_key"},
+	"write:ssh_signing_key":     {"read:ssh_signing_key"},
+	"project":                   {"read:project"},
+	"admin:enterprise":          {"manage_runners:enterprise", "manage_billing:enterprise", "read:enterprise"},
+	"manage_billing:enterprise": {"read:enterprise"},
+}
+
+// permissionLevels orders the access levels of fine-grained permissions.
+var permissionLevels = map[string]int{"read": 1, "write": 2, "admin": 3}
+
+// expandScopes returns the scopes with every scope they imply.
+func expandScopes(scopes []string) map[string]bool {
+	granted := map[string]bool{}
+	var add func(scope string)
+	add = func(scope string) {
+		if granted[scope] {
+			return
+		}
+		granted[scope] = true
+		for _, implied := range impliedScopes[scope] {
+			add(implied)
+		}
+	}
+	for _, scope := range scopes {
+		add(scope)
+	}
+	return granted
+}
+
+// tableAccess returns whether each table of the plugin can be queried with a
+// credential's scopes or permissions, or nil for a table when that can't be
+// determined.
+func tableAccess(p *tokenPermission, tables map[string]*plugin.Table) map[string]*bool {
+	var granted map[string]bool
+	if p.Scopes != nil {
+		granted = expandScopes(p.Scopes)
+	}
+
+	access := map[string]*bool{}
+	for table := range tables {
+		required, ok := requiredTablePermissions[table]
+		if !ok {
+			access[table] = nil
+			continue
+		}
+
+		var queryable *bool
+		switch {
+		case len(required.Scopes) == 0 && len(required.Permissions) == 0:
+			queryable = boolPtr(true)
+		case granted != nil:
+			queryable = boolPtr(len(required.Scopes) == 0)
+			for _, scope := range required.Scopes {
+				if granted[scope] {
+					queryable = boolPtr(true)
+					break
+				}
+			}
+		case p.Permissions != nil:
+			queryable = boolPtr(len(required.Permissions) > 0)
+			for permission, level := range required.Permissions {
+				if p.probed != nil && !p.probed[permission] {
+					queryable = nil
+					continue
+				}
+				if permissionLevels[p.Permissions[permission]] < permissionLevels[level] {
+					queryable = boolPtr(false)
+					break
+				}
+			}
+		}
+		access[table] = queryable
+	}
+	return access
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// getTokenPermission looks up the scopes or permissions of a credential. It
+// sends its own requests with the credential, so that every credential of
+// the pool is inspected rather than the one with the most budget.
+func getTokenPermission(ctx context.Context, d *plugin.QueryData, config *clientConfig, c *credential) (*tokenPermission, error) {
+	p := &tokenPermission{
+		Credential: c.name,
+		TokenType:  c.kind,
+	}
+	// The plugin's table map is only built for each connection, so the
+	// tables are those of the query's connection
+	tables, err := tableMap(ctx, &plugin.TableMapData{Connection: d.Connection})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.kind == credentialKindAppInstallation {
+		installations, err := getAppInstallations(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		req, err := installations.client.NewRequest("GET", fmt.Sprintf("app/installations/%d", c.installationID), nil)
+		if err != nil {
+			return nil, err
+		}
+		// Decode the permissions into a map rather than
+		// github.InstallationPermissions, so that permissions added after the
+		// client library was released are not dropped
+		var installation struct {
+			Permissions         map[string]string `json:"permissions"`
+			RepositorySelection *string           `json:"repository_selection"`
+		}
+		if _, err := installations.client.Do(ctx, req, &installation); err != nil {
+			return nil, err
+		}
+		p.InstallationID = &c.installationID
+		p.Permissions = installation.Permissions
+		p.RepositorySelection = installation.RepositorySelection
+		if p.Permissions == nil {
+			p.Permissions = map[string]string{}
+		}
+		p.TableAccess = tableAccess(p, tables)
+		return p, nil
+	}
+
+	client, err := newRESTClient(config, &http.Client{Transport: c.transport})
+	if err != nil {
+		return nil, err
+	}
+
+	// The rate limit endpoint doesn't count against the rate limit, and its
+	// response carries the scope and expiration headers of the token
+	req, err := client.NewRequest("GET", "rate_limit", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	if scopes, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		p.Scopes = []string{}
+		for _, scope := range strings.Split(strings.Join(scopes, ","), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				p.Scopes = append(p.Scopes, scope)
+			}
+		}
+		sort.Strings(p.Scopes)
+	}
+	if expiration := resp.Header.Get("GitHub-Authentication-Token-Expiration"); expiration != "" {
+		if expiresAt, err := time.Parse("2006-01-02 15:04:05 MST", expiration); err == nil {
+			p.ExpiresAt = &expiresAt
+		}
+	}
+
+	if c.kind == credentialKindInstallationAccessToken {
+		req, err := client.NewRequest("GET", "installation/repositories?per_page=1", nil)
+		if err != nil {
+			return nil, err
+		}
+		var repositories struct {
+			RepositorySelection *string              `json:"repository_selection"`
+			Repositories        []*github.Repository `json:"repositories"`
+		}
+		if _, err := client.Do(ctx, req, &repositories); err != nil {
+			return nil, err
+		}
+		p.RepositorySelection = repositories.RepositorySelection
+		if err := probePermissions(ctx, client, p, repositories.Repositories); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.kind == credentialKindFineGrainedPersonalAccessToken {
+		repositories, _, err := client.Repositories.List(ctx, "", &github.RepositoryListOptions{ListOptions: github.ListOptions{PerPage: 1}})
+		if err != nil {
+			return nil, err
+		}
+		if err := probePermissions(ctx, client, p, repositories); err != nil {
+			return nil, err
+		}
+	}
+
+	p.TableAccess = tableAccess(p, tables)
+	return p, nil
+}
+
+// permissionProbe is a read only request that a token can only make if it
+// has been granted read access for the permission. The path is formatted with
+// the full name of a repository, or the login of an organization, the token
+// can access.
+type permissionProbe struct {
+	permission string
+	path       string
+}
+
+var repositoryPermissionProbes = []permissionProbe{
+	{"metadata", "repos/%s"},
+	{"contents", "repos/%s/commits?per_page=1"},
+	{"issues", "repos/%s/issues?per_page=1"},
+	{"pull_requests", "repos/%s/pulls?per_page=1"},
+	{"actions", "repos/%s/actions/runs?per_page=1"},
+	{"administration", "repos/%s/actions/runners?per_page=1"},
+	{"checks", "repos/%s/commits/HEAD/check-suites?per_page=1"},
+	{"statuses", "repos/%s/commits/HEAD/statuses?per_page=1"},
+	{"deployments", "repos/%s/deployments?per_page=1"},
+	{"environments", "repos/%s/environments?per_page=1"},
+	{"secrets", "repos/%s/actions/secrets?per_page=1"},
+	{"actions_variables", "repos/%s/actions/variables?per_page=1"},
+	{"dependabot_secrets", "repos/%s/dependabot/secrets?per_page=1"},
+	{"repository_hooks", "repos/%s/hooks?per_page=1"},
+	{"security_events", "repos/%s/code-scanning/alerts?per_page=1"},
+	{"secret_scanning_alerts", "repos/%s/secret-scanning/alerts?per_page=1"},
+	{"vulnerability_alerts", "repos/%s/dependabot/alerts?per_page=1"},
+}
+
+var organizationPermissionProbes = []permissionProbe{
+	{"members", "orgs/%s/members?per_page=1"},
+	{"organization_administration", "orgs/%s/actions/permissions"},
+	{"organization_secrets", "orgs/%s/actions/secrets?per_page=1"},
+	{"organization_actions_variables", "orgs/%s/actions/variables?per_page=1"},
+	{"organization_dependabot_secrets", "orgs/%s/dependabot/secrets?per_page=1"},
+	{"organization_codespaces_secrets", "orgs/%s/codespaces/secrets?per_page=1"},
+	{"organization_self_hosted_runners", "orgs/%s/actions/runners?per_page=1"},
+	{"organization_hooks", "orgs/%s/hooks?per_page=1"},
+}
+
+// userPermissionProbes are only made for fine-grained personal access tokens,
+// as installation access tokens don't act for a user.
+var userPermissionProbes = []permissionProbe{
+	{"gpg_keys", "user/gpg_keys?per_page=1"},
+	{"git_ssh_keys", "user/keys?per_page=1"},
+	{"ssh_signing_keys", "user/ssh_signing_keys?per_page=1"},
+	{"starring", "user/starred?per_page=1"},
+}
+
+// probePermissions derives the read permissions of a token that doesn't
+// expose them from which probes it is allowed to make. Repository and
+// organization permissions are probed against the first repository the token
+// can access, and its owner if that is an organization. A probe that is
+// denied with a 403 means the permission is not granted, whereas any other
+// error, such as a 404 for a feature that isn't enabled, leaves the
+// permission unknown, as do permissions that have no probe.
+func probePermissions(ctx context.Context, client *github.Client, p *tokenPermission, repositories []*github.Repository) error {
+	p.Permissions = map[string]string{}
+	p.probed = map[string]bool{}
+
+	var probes []permissionProbe
+	if len(repositories) > 0 {
+		repository := repositories[0]
+		for _, probe := range repositoryPermissionProbes {
+			probes = append(probes, permissionProbe{probe.permission, fmt.Sprintf(probe.path, repository.GetFullName())})
+		}
+		if repository.GetOwner().GetType() == "Organization" {
+			for _, probe := range organizationPermissionProbes {
+				probes = append(probes, permissionProbe{probe.permission, fmt.Sprintf(probe.path, repository.GetOwner().GetLogin())})
+			}
+		}
+	}
+	if p.TokenType == credentialKindFineGrainedPersonalAccessToken {
+		probes = append(probes, userPermissionProbes...)
+	}
+
+	for _, probe := range probes {
+		req, err := client.NewRequest("GET", probe.path, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(ctx, req, nil)
+		var errorResponse *github.ErrorResponse
+		switch {
+		case err == nil:
+			p.Permissions[probe.permission] = "read"
+			p.probed[probe.permission] = true
+		case errors.As(err, &errorResponse) && resp.StatusCode == http.StatusForbidden:
+			p.probed[probe.permission] = true
+		case errorResponse == nil:
+			// Rate limits and network errors say nothing about the token
+			return err
+		}
+		plugin.Logger(ctx).Debug("probePermissions", "credential", p.Credential, "permission", probe.permission, "granted", p.Permissions[probe.permission] != "")
+	}
+	return nil
+}
@@ -3,9 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
-	"os"
 	"slices"
-	"strings"
 
 	"github.com/shurcooL/githubv4"
 	"github.com/turbot/steampipe-plugin-github/github/models"
@@ -27,18 +25,18 @@ func extractUserFromHydrateItem(h *plugin.HydrateData) (models.UserWithCounts, e
 // With Fine-grained access token we are getting field error even though we have proper access. For the column "assignees" column table "github_issue". so we need to avoid this error
 // https://spec.graphql.org/October2021/#sec-Errors.Field-errors
 // https://spec.graphql.org/October2021/#sec-Handling-Field-Errors
-func appendUserInteractionAbilityForIssue(m *map[string]interface{}, cols []string, d *plugin.QueryData) {
-	githubConfig := GetConfig(d.Connection)
-	token := os.Getenv("GITHUB_TOKEN")
-	if slices.Contains(cols, "assignees") && (isGitHubPAT(token) || isGitHubPAT(*githubConfig.Token)) {
+// The kind of token is that of the credential the query is pinned to.
+func appendUserInteractionAbilityForIssue(ctx context.Context, m *map[string]interface{}, cols []string, d *plugin.QueryData) error {
+	q, err := getQueryCredential(ctx, d)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(cols, "assignees") && q.current().kind == credentialKindFineGrainedPersonalAccessToken {
 		(*m)["includeUserInteractionAbility"] = githubv4.Boolean(false)
 	} else {
 		(*m)["includeUserInteractionAbility"] = githubv4.Boolean(true)
 	}
-}
-
-func isGitHubPAT(token string) bool {
-	return strings.HasPrefix(token, "github_pat")
+	return nil
 }
 
 func appendUserColumnIncludes(m *map[string]interface{}, cols []string) {
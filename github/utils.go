@@ -4,9 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
-	"os"
-	"strconv"
 	"strings"
 	"time"
 
@@ -51,106 +48,48 @@ func isValidInstallationOrOAuthAccessTokenPrefix(token string) bool {
 }
 
 // Create Rest API (v3) client
-func connect(ctx context.Context, d *plugin.QueryData) *github.Client {
-
-	// Load connection from cache, which preserves throttling protection etc
-	cacheKey := "github_v3"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
-		return cachedData.(*github.Client)
-	}
-
-	token := os.Getenv("GITHUB_TOKEN")
-	baseURL := os.Getenv("GITHUB_BASE_URL")
-	appId := os.Getenv("GITHUB_APP_ID")
-	installationId := os.Getenv("GITHUB_APP_INSTALLATION_ID")
-	privateKeyPath := os.Getenv("GITHUB_APP_PEM_FILE")
-
-	var githubAppId, githubInstallationId, githubPrivateKeyPath string
-
-	if appId != "" {
-		githubAppId = appId
-	}
-	if installationId != "" {
-		githubInstallationId = installationId
-	}
-	if privateKeyPath != "" {
-		githubPrivateKeyPath = privateKeyPath
+func connect(ctx context.Context, d *plugin.QueryData) (*github.Client, error) {
+	q, err := getQueryCredential(ctx, d)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get connection config for plugin
-	githubConfig := GetConfig(d.Connection)
-	if githubConfig.Token != nil {
-		token = *githubConfig.Token
-	}
-	if githubConfig.BaseURL != nil {
-		baseURL = *githubConfig.BaseURL
+	config, err := getClientConfig(ctx, d)
+	if err != nil {
+		return nil, err
 	}
 
-	//// Github App authentication.
-	if githubConfig.AppId != nil {
-		githubAppId = *githubConfig.AppId
-	}
-	if githubConfig.InstallationId != nil {
-		githubInstallationId = *githubConfig.InstallationId
-	}
-	if githubConfig.PrivateKey != nil {
-		githubPrivateKeyPath = *githubConfig.PrivateKey
-	}
-
-	if token == "" && (githubAppId == "" || githubInstallationId == "" || githubPrivateKeyPath == "") {
-		panic("'token' or 'app_id', 'installation_id' and 'private_key' must be set in the connection configuration. Edit your connection configuration file and then restart Steampipe")
-	}
-
-	// Return error for unsupported token by prefix
-	// https://docs.github.com/en/authentication/keeping-your-account-and-data-secure/about-authentication-to-github#githubs-token-formats
-	if token != "" && !strings.HasPrefix(token, "ghs_") && !strings.HasPrefix(token, "ghp_") && !strings.HasPrefix(token, "gho_") && !strings.HasPrefix(token, "github_pat") {
-		panic("Supported token formats are 'ghs_', 'gho_', 'ghp_', and 'github_pat'")
-	}
-
-	var client *github.Client
+	return queryRESTClient(config, q)
+}
 
-	// Authentication with Github access token
-	if token != "" && isValidPersonalAccessTokenPrefix(token) {
-		ts := oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: token},
-		)
-		tc := oauth2.NewClient(ctx, ts)
-		client = github.NewClient(tc)
+// queryRESTClient returns the REST client that sends the requests of a query
+// with its credential.
+func queryRESTClient(config *clientConfig, q *queryCredential) (*github.Client, error) {
+	// Reuse the query's client, which preserves throttling protection etc
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.rest != nil {
+		return q.rest, nil
 	}
 
-	// Authentication Using App Installation Access Token or OAuth Access token
-	if token != "" && isValidInstallationOrOAuthAccessTokenPrefix(token) {
-		client = github.NewClient(&http.Client{Transport: &oauth2Transport{
-			Token: token,
-		}})
+	client, err := newRESTClient(config, &http.Client{Transport: q})
+	if err != nil {
+		return nil, err
 	}
+	q.rest = client
 
-	// Authentication as Github APP Installation authentication
-	if githubAppId != "" && githubInstallationId != "" && githubPrivateKeyPath != "" && token == "" {
-		ghAppId, err := strconv.ParseInt(githubAppId, 10, 64)
-		if err != nil {
-			panic(err)
-		}
-		ghInstallationId, err := strconv.ParseInt(githubInstallationId, 10, 64)
-		if err != nil {
-			panic(err)
-		}
-		itr, err := ghinstallation.NewKeyFromFile(http.DefaultTransport, ghAppId, ghInstallationId, githubPrivateKeyPath)
-		if err != nil {
-			panic("Error occurred in 'connect()' during GitHub App Installation client creation: " + err.Error())
-		}
+	return client, nil
+}
 
-		client = github.NewClient(&http.Client{Transport: itr})
-	}
+// newRESTClient creates a REST API client that sends requests with the HTTP
+// client, to the enterprise install at the base URL if one is configured.
+func newRESTClient(config *clientConfig, httpClient *http.Client) (*github.Client, error) {
+	client := github.NewClient(httpClient)
 
 	// If the base URL was provided then set it on the client. Used for
 	// enterprise installs.
-	if baseURL != "" {
-		uv4, err := url.Parse(baseURL)
-		if err != nil {
-			panic(fmt.Sprintf("github.base_url is invalid: %s", baseURL))
-		}
-
+	if config.BaseURL != nil {
+		uv4 := *config.BaseURL
 		if uv4.String() != "https://api.github.com/" {
 			uv4.Path = uv4.Path + "api/v3/"
 		}
@@ -158,150 +97,183 @@ func connect(ctx context.Context, d *plugin.QueryData) *github.Client {
 		// The upload URL is not set as it's not currently required
 		conn, err := github.NewClient(client.Client()).WithEnterpriseURLs(uv4.String(), "")
 		if err != nil {
-			panic(fmt.Sprintf("error creating GitHub client: %v", err))
+			return nil, fmt.Errorf("error creating GitHub client: %v", err)
 		}
-		conn.BaseURL = uv4
+		conn.BaseURL = &uv4
 		client = conn
 	}
 
-	// Save to cache
-	d.ConnectionManager.Cache.Set(cacheKey, client)
-
-	return client
+	return client, nil
 }
 
 // Create GraphQL API (v4) client
-func connectV4(ctx context.Context, d *plugin.QueryData) *githubv4.Client {
+func connectV4(ctx context.Context, d *plugin.QueryData) (*githubv4.Client, error) {
+	q, err := getQueryCredential(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
-	// Load connection from cache, which preserves throttling protection etc
-	cacheKey := "github_v4"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
-		return cachedData.(*githubv4.Client)
+	// Reuse the query's client
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.v4 != nil {
+		return q.v4, nil
 	}
 
-	token := os.Getenv("GITHUB_TOKEN")
-	baseURL := os.Getenv("GITHUB_BASE_URL")
-	appId := os.Getenv("GITHUB_APP_ID")
-	installationId := os.Getenv("GITHUB_APP_INSTALLATION_ID")
-	privateKeyPath := os.Getenv("GITHUB_APP_PEM_FILE")
+	config, err := getClientConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
-	var githubAppId, githubInstallationId, githubPrivateKeyPath string
+	httpClient := &http.Client{Transport: q}
+	client := githubv4.NewClient(httpClient)
 
-	if appId != "" {
-		githubAppId = appId
-	}
-	if installationId != "" {
-		githubInstallationId = installationId
-	}
-	if privateKeyPath != "" {
-		githubPrivateKeyPath = privateKeyPath
+	// If the base URL was provided then set it on the client. Used for
+	// enterprise installs.
+	if config.BaseURL != nil {
+		uv4 := *config.BaseURL
+		if uv4.String() != "https://api.github.com/" {
+			uv4.Path = uv4.Path + "api/graphql"
+		}
+		client = githubv4.NewEnterpriseClient(uv4.String(), httpClient)
 	}
+	q.v4 = client
 
-	// Get connection config for plugin
-	githubConfig := GetConfig(d.Connection)
-	if githubConfig.Token != nil {
-		token = *githubConfig.Token
-	}
-	if githubConfig.BaseURL != nil {
-		baseURL = *githubConfig.BaseURL
-	}
+	return client, nil
+}
 
-	// Github App authentication.
-	if githubConfig.AppId != nil {
-		githubAppId = *githubConfig.AppId
-	}
-	if githubConfig.InstallationId != nil {
-		githubInstallationId = *githubConfig.InstallationId
-	}
-	if githubConfig.PrivateKey != nil {
-		githubPrivateKeyPath = *githubConfig.PrivateKey
+// getClientConfig returns the validated connection config. It is loaded once
+// per connection, when the first client is created, and any error names the
+// offending connection config argument.
+func getClientConfig(ctx context.Context, d *plugin.QueryData) (*clientConfig, error) {
+
+	// Load client config from cache
+	cacheKey := "github_client_config"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*clientConfig), nil
 	}
 
-	// Return error for unsupported token by prefix
-	// https://docs.github.com/en/authentication/keeping-your-account-and-data-secure/about-authentication-to-github#githubs-token-formats
-	if token != "" && !strings.HasPrefix(token, "ghs_") && !strings.HasPrefix(token, "ghp_") && !strings.HasPrefix(token, "gho_") && !strings.HasPrefix(token, "github_pat") {
-		panic("Supported token formats are 'ghs_', 'gho_', 'ghp_', and 'github_pat'")
+	config, err := loadClientConfig(GetConfig(d.Connection))
+	if err != nil {
+		plugin.Logger(ctx).Error("getClientConfig", "connection", d.Connection.Name, "config_error", err)
+		return nil, err
 	}
 
-	var client *githubv4.Client
+	// Save to cache
+	d.ConnectionManager.Cache.Set(cacheKey, config)
 
-	// Authentication Using App Installation Access Token or OAuth Access token
-	if token != "" && isValidInstallationOrOAuthAccessTokenPrefix(token) {
-		return githubv4.NewClient(&http.Client{Transport: &oauth2Transport{
-			Token: token,
-		}})
+	return config, nil
+}
+
+// getQueryCredential returns the credential every request of the query is
+// sent with. The REST and GraphQL clients of the query share it, so that the
+// rate limit budget seen by one is taken into account by the other.
+func getQueryCredential(ctx context.Context, d *plugin.QueryData) (*queryCredential, error) {
+	pool, err := getCredentialPool(ctx, d)
+	if err != nil {
+		return nil, err
 	}
+	return pool.forQuery(d.QueryContext), nil
+}
 
-	var transport *ghinstallation.Transport
+// viewerCacheKey returns the connection cache key of data that depends on
+// the identity the query authenticates as, such as the authenticated user,
+// which differs between the credentials of a connection.
+func viewerCacheKey(ctx context.Context, d *plugin.QueryData, key string) (string, error) {
+	q, err := getQueryCredential(ctx, d)
+	if err != nil {
+		return "", err
+	}
+	return key + "_" + q.current().name, nil
+}
 
-	// Authentication with Github access token
-	if token != "" && isValidPersonalAccessTokenPrefix(token) {
-		ts := oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: token},
-		)
-		tc := oauth2.NewClient(ctx, ts)
-		client = githubv4.NewClient(tc)
+// getCredentialPool returns the pool of credentials the query is pinned to
+// one of.
+func getCredentialPool(ctx context.Context, d *plugin.QueryData) (*credentialPool, error) {
+	config, err := getClientConfig(ctx, d)
+	if err != nil {
+		return nil, err
 	}
 
-	if token == "" && (githubAppId == "" || githubInstallationId == "" || githubPrivateKeyPath == "") {
-		panic("'token' or 'app_id', 'installation_id' and 'private_key' must be set in the connection configuration. Edit your connection configuration file and then restart Steampipe")
+	// Route the query to the installation of the app that owns the
+	// organization or repository it is scoped to
+	if config.DiscoverInstallations {
+		return getInstallationCredentialPool(ctx, d, config)
 	}
 
-	// Authentication as Github APP Installation
-	if githubAppId != "" && githubInstallationId != "" && githubPrivateKeyPath != "" && token == "" {
-		ghAppId, err := strconv.ParseInt(githubAppId, 10, 64)
-		if err != nil {
-			panic(err)
-		}
-		ghInstallationId, err := strconv.ParseInt(githubInstallationId, 10, 64)
-		if err != nil {
-			panic(err)
+	// Load credential pool from cache
+	cacheKey := "github_credential_pool"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*credentialPool), nil
+	}
+
+	var credentials []*credential
+
+	for i, token := range config.Tokens {
+		var transport http.RoundTripper
+
+		// Authentication with Github access token
+		if isValidPersonalAccessTokenPrefix(token) {
+			transport = &oauth2.Transport{
+				Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+				Base:   config.Transport,
+			}
 		}
-		itr, err := ghinstallation.NewKeyFromFile(http.DefaultTransport, ghAppId, ghInstallationId, githubPrivateKeyPath)
-		if err != nil {
-			panic("Error occurred in 'connectV4()' during GitHub App Installation client creation" + err.Error())
+
+		// Authentication Using App Installation Access Token or OAuth Access token
+		if isValidInstallationOrOAuthAccessTokenPrefix(token) {
+			transport = &oauth2Transport{
+				Token: token,
+				Base:  config.Transport,
+			}
 		}
-		transport = itr
 
-		client = githubv4.NewClient(&http.Client{Transport: itr})
+		credentials = append(credentials, &credential{
+			name:      fmt.Sprintf("token %d", i),
+			kind:      tokenCredentialKind(token),
+			transport: config.HTTPCache.transport(transport, "token "+token),
+		})
 	}
 
-	// If the base URL was provided then set it on the client. Used for
-	// enterprise installs.
-	if baseURL != "" {
-		uv4, err := url.Parse(baseURL)
+	// Authentication as Github APP Installation
+	if len(config.InstallationIds) > 0 {
+		atr, err := newAppsTransport(config)
 		if err != nil {
-			panic(fmt.Sprintf("github.base_url is invalid: %s", baseURL))
+			return nil, err
 		}
-
-		if uv4.String() != "https://api.github.com/" {
-			uv4.Path = uv4.Path + "api/graphql"
-		}
-		if token != "" {
-			ts := oauth2.StaticTokenSource(
-				&oauth2.Token{AccessToken: token},
-			)
-			tc := oauth2.NewClient(ctx, ts)
-			client = githubv4.NewEnterpriseClient(uv4.String(), tc)
-		} else {
-			client = githubv4.NewEnterpriseClient(uv4.String(), &http.Client{Transport: transport})
+		for _, installationId := range config.InstallationIds {
+			credentials = append(credentials, &credential{
+				name:           fmt.Sprintf("installation %d", installationId),
+				kind:           credentialKindAppInstallation,
+				transport:      config.HTTPCache.transport(ghinstallation.NewFromAppsTransport(atr, installationId), installationCacheCredential(config, installationId)),
+				installationID: installationId,
+			})
 		}
 	}
 
+	plugin.Logger(ctx).Debug("getCredentialPool", "credentials", len(credentials), "wait_for_reset", config.WaitForReset, "max_wait", config.MaxWait.String(), "min_remaining", config.MinRemaining)
+	pool := newCredentialPool("default", credentials, config.WaitForReset, config.MaxWait, config.MinRemaining)
+
 	// Save to cache
-	d.ConnectionManager.Cache.Set(cacheKey, client)
+	d.ConnectionManager.Cache.Set(cacheKey, pool)
 
-	return client
+	return pool, nil
 }
 
 // oauth2Transport is an http.RoundTripper that authenticates all requests
 type oauth2Transport struct {
 	Token string
+	// Base is the transport the requests are sent with, defaults to
+	// http.DefaultTransport
+	Base http.RoundTripper
 }
 
 func (t *oauth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	clone := req.Clone(req.Context())
 	clone.Header.Set("Authorization", "Bearer "+t.Token)
+	if t.Base != nil {
+		return t.Base.RoundTrip(clone)
+	}
 	return http.DefaultTransport.RoundTrip(clone)
 }
 